@@ -0,0 +1,181 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+	"unsafe"
+)
+
+var (
+	Err_Empty_Sample            = errors.New("dam: the sample of keys is empty")
+	Err_Memory_Budget_Too_Small = errors.New("dam: no bucket geometry fits in the memory budget")
+)
+
+// Describes the workload `Auto_Tune` measures the trial maps against.
+//
+// The zero value weighs gets and sets equally, sizes for the sample itself and has no memory budget.
+type T_Tune_Config struct {
+	// The relative number of `Get` and `Set` calls in the workload, e.g. 9 and 1 for a read-heavy map.
+	// When both are 0, gets and sets are weighed equally.
+	Gets uint64
+	Sets uint64
+
+	// The number of entries the real map will hold, the measured memory is scaled up to it.
+	// With 0, the size of the sample is used.
+	Expected_Num_Inputs uint64
+
+	// The most bytes the real map may use for its buckets, 0 means there is no budget.
+	Memory_Budget_Bytes uint64
+
+	// How many times every trial is repeated, the fastest run is kept. 0 means 3.
+	Rounds int
+}
+
+// The measurements of one trial map.
+type T_Tune_Trial struct {
+	Geometry T_Bucket_Geometry
+	Mixer    T_Hash_Mixer
+
+	Ns_Per_Get float64
+	Ns_Per_Set float64
+
+	// The bytes the real map would use for its buckets, scaled from the trial map.
+	Memory_Bytes uint64
+	// Whether `Memory_Bytes` fits in `T_Tune_Config.Memory_Budget_Bytes`.
+	Within_Budget bool
+}
+
+type T_Tune_Result struct {
+	// The trial with the lowest weighted cost that fits in the memory budget.
+	Best T_Tune_Trial
+	// Every trial, in the order they were run.
+	Trials []T_Tune_Trial
+}
+
+// The geometries and mixers `Auto_Tune` tries, every combination is measured.
+var (
+	tune_entries_per_bucket = [...]uint64{1, 2, 4, 8, 16}
+	tune_mixers             = [...]T_Hash_Mixer{
+		HASH_MIXER__IDENTITY,
+		HASH_MIXER__FIBONACCI,
+		HASH_MIXER__MURMUR3,
+		HASH_MIXER__SPLITMIX64,
+	}
+)
+
+// Pick a bucket geometry and hash mixer for a workload by building a trial `DAM` for every
+// combination, timing `Set` and `Get` over `sample_keys` and keeping the cheapest one.
+//
+// The gets visit the sample in a shuffled order so that they miss the cache like real lookups do.
+// Use `Tuned_Options` to turn the result into options for `New`.
+//
+// - NOTE: This takes time proportional to the size of the sample, a few hundred thousand keys is plenty.
+func Auto_Tune[KT I_Positive_Integer, VT any](sample_keys []KT, config T_Tune_Config) (T_Tune_Result, error) {
+	if len(sample_keys) == 0 {
+		return T_Tune_Result{}, Err_Empty_Sample
+	}
+
+	gets, sets := config.Gets, config.Sets
+	if gets == 0 && sets == 0 {
+		gets, sets = 1, 1
+	}
+	expected := config.Expected_Num_Inputs
+	if expected == 0 {
+		expected = uint64(len(sample_keys))
+	}
+	rounds := config.Rounds
+	if rounds <= 0 {
+		rounds = 3
+	}
+
+	// Always the same order, so runs are comparable...
+	rng := rand.New(rand.NewSource(1))
+	get_order := make([]KT, len(sample_keys))
+	for i, j := range rng.Perm(len(sample_keys)) {
+		get_order[i] = sample_keys[j]
+	}
+
+	var result T_Tune_Result
+	best_cost := math.Inf(1)
+	for _, entries_per_bucket := range tune_entries_per_bucket {
+		for _, mixer := range tune_mixers {
+			trial := T_Tune_Trial{
+				Geometry: T_Bucket_Geometry{
+					Entries_Per_Bucket:      entries_per_bucket,
+					Initial_Bucket_Capacity: entries_per_bucket,
+				},
+				Mixer:      mixer,
+				Ns_Per_Get: math.Inf(1),
+				Ns_Per_Set: math.Inf(1),
+			}
+			for r := 0; r < rounds; r++ {
+				ns_per_set, ns_per_get, memory := run_tune_trial[KT, VT](sample_keys, get_order, trial.Geometry, mixer)
+				trial.Ns_Per_Set = min(trial.Ns_Per_Set, ns_per_set)
+				trial.Ns_Per_Get = min(trial.Ns_Per_Get, ns_per_get)
+				trial.Memory_Bytes = memory * expected / uint64(len(sample_keys))
+			}
+			trial.Within_Budget = config.Memory_Budget_Bytes == 0 || trial.Memory_Bytes <= config.Memory_Budget_Bytes
+			result.Trials = append(result.Trials, trial)
+
+			cost := float64(gets)*trial.Ns_Per_Get + float64(sets)*trial.Ns_Per_Set
+			if trial.Within_Budget && cost < best_cost {
+				best_cost = cost
+				result.Best = trial
+			}
+		}
+	}
+
+	if math.IsInf(best_cost, 1) {
+		return result, Err_Memory_Budget_Too_Small
+	}
+	return result, nil
+}
+
+// Returns the time per `Set` and per `Get` in nanoseconds, and the bytes used by the buckets.
+func run_tune_trial[KT I_Positive_Integer, VT any](
+	sample_keys []KT,
+	get_order []KT,
+	geometry T_Bucket_Geometry,
+	mixer T_Hash_Mixer,
+) (float64, float64, uint64) {
+	m := New(
+		KT(min(uint64(len(sample_keys)), uint64(^KT(0)))),
+		With_Bucket_Geometry[KT, VT](geometry.Entries_Per_Bucket, geometry.Initial_Bucket_Capacity),
+		With_Hash_Mixer[KT, VT](mixer),
+	)
+
+	var v VT
+	start := time.Now()
+	for _, k := range sample_keys {
+		m.Set(k, v)
+	}
+	ns_per_set := float64(time.Since(start).Nanoseconds()) / float64(len(sample_keys))
+
+	start = time.Now()
+	for _, k := range get_order {
+		if _, ok := m.Get(k); !ok {
+			panic("Key not found.")
+		}
+	}
+	ns_per_get := float64(time.Since(start).Nanoseconds()) / float64(len(get_order))
+
+	s := m.Stats()
+	memory := s.Capacity_Bytes + s.Num_Buckets*uint64(unsafe.Sizeof(bucket[KT, VT]{}))
+
+	return ns_per_set, ns_per_get, memory
+}
+
+// Returns the options that build a `DAM` with the geometry and mixer of a trial.
+func Tuned_Options[KT I_Positive_Integer, VT any](trial T_Tune_Trial) []T_Option[KT, VT] {
+	return []T_Option[KT, VT]{
+		With_Bucket_Geometry[KT, VT](trial.Geometry.Entries_Per_Bucket, trial.Geometry.Initial_Bucket_Capacity),
+		With_Hash_Mixer[KT, VT](trial.Mixer),
+	}
+}
@@ -0,0 +1,232 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import (
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+const cache_line_size = 64
+
+// Guards a contiguous run of buckets, padded so that neighbouring stripes do not share a cache line.
+type t_stripe struct {
+	mu sync.RWMutex
+	_  [cache_line_size - unsafe.Sizeof(sync.RWMutex{})%cache_line_size]byte
+}
+
+// A `DAM` that is safe for concurrent use.
+//
+// The buckets are split into lock stripes, the stripe of a bucket being the high bits of its index,
+// so `Get` only read-locks one stripe and writers only block the keys of their own stripe.
+// Growing the map locks every stripe and rehashes all entries at once.
+type Concurrent_DAM[KT I_Positive_Integer, VT any] struct {
+	// Holds the buckets and the hashing configuration, its own counters are not used.
+	map_ *DAM[KT, VT]
+
+	stripes     []t_stripe
+	stripe_bits int
+
+	// Only written while every stripe is locked, so that the stripe of a key can be picked before locking it.
+	num_buckets_m1      atomic.Uint64
+	grow_at_num_entries atomic.Int64
+
+	num_entries atomic.Int64
+}
+
+// Accepts the same options as `New`, except `With_Range_Reduction` and `With_Incremental_Resize`.
+//
+// `num_stripes` is rounded up to a power of two, with 0 it is 4 times `runtime.GOMAXPROCS(0)`.
+//
+// - NOTE: Panics if the options are invalid.
+func New_Concurrent_DAM[KT I_Positive_Integer, VT any](
+	expected_num_inputs KT,
+	num_stripes int,
+	options ...T_Option[KT, VT],
+) *Concurrent_DAM[KT, VT] {
+	for _, opt := range options {
+		switch opt.t {
+		case OPTION_TYPE__WITH_RANGE_REDUCTION:
+			if enabled, _ := opt.other.(bool); enabled {
+				panic(&T_Invalid_Option_Error{opt.t, "`Concurrent_DAM` picks stripes from the high bits of the bucket index, which needs a power of two bucket count"})
+			}
+		case OPTION_TYPE__WITH_INCREMENTAL_RESIZE:
+			if step, _ := opt.other.(uint64); step != 0 {
+				panic(&T_Invalid_Option_Error{opt.t, "`Concurrent_DAM` resizes with every stripe locked"})
+			}
+		}
+	}
+
+	if num_stripes <= 0 {
+		num_stripes = 4 * runtime.GOMAXPROCS(0)
+	}
+	stripe_bits := bits.Len64(uint64(num_stripes - 1))
+
+	inst := Concurrent_DAM[KT, VT]{
+		map_:        New(expected_num_inputs, options...),
+		stripes:     make([]t_stripe, 1<<stripe_bits),
+		stripe_bits: stripe_bits,
+	}
+	inst.publish_geometry()
+
+	return &inst
+}
+
+// Copy the bucket count and growth threshold of the inner map, with every stripe locked.
+func (c *Concurrent_DAM[KT, VT]) publish_geometry() {
+	c.num_buckets_m1.Store(c.map_.num_buckets_m1)
+	c.grow_at_num_entries.Store(int64(c.map_.grow_at_num_entries))
+}
+
+// Returns the stripe guarding bucket `index`, out of `num_buckets_m1 + 1` buckets.
+//
+// When there are fewer buckets than stripes, only the first stripes are used.
+//
+//go:inline
+func (c *Concurrent_DAM[KT, VT]) stripe_of(index uint64, num_buckets_m1 uint64) *t_stripe {
+	index_bits := bits.Len64(num_buckets_m1)
+	return &c.stripes[index>>(index_bits-min(c.stripe_bits, index_bits))]
+}
+
+// Lock the stripe of `key` and return it with the bucket the key belongs to.
+//
+// The bucket count is read before locking, so it is checked again once the stripe is held;
+// a resize in between holds every stripe, so seeing the same count means no resize happened.
+func (c *Concurrent_DAM[KT, VT]) lock_bucket_of(key KT, write bool) (*t_stripe, *bucket[KT, VT]) {
+	h := c.map_.hash(key)
+	for {
+		num_buckets_m1 := c.num_buckets_m1.Load()
+		index := c.map_.reduce(h, num_buckets_m1)
+		s := c.stripe_of(index, num_buckets_m1)
+
+		if write {
+			s.mu.Lock()
+		} else {
+			s.mu.RLock()
+		}
+		if c.num_buckets_m1.Load() == num_buckets_m1 {
+			return s, &c.map_.buckets[index]
+		}
+		if write {
+			s.mu.Unlock()
+		} else {
+			s.mu.RUnlock()
+		}
+	}
+}
+
+func (c *Concurrent_DAM[KT, VT]) lock_all() {
+	for i := range c.stripes {
+		c.stripes[i].mu.Lock()
+	}
+}
+
+func (c *Concurrent_DAM[KT, VT]) unlock_all() {
+	for i := range c.stripes {
+		c.stripes[i].mu.Unlock()
+	}
+}
+
+// Double the number of buckets with every stripe locked, unless another writer already did.
+func (c *Concurrent_DAM[KT, VT]) grow() {
+	c.lock_all()
+	defer c.unlock_all()
+
+	if c.num_entries.Load() <= c.grow_at_num_entries.Load() {
+		return
+	}
+	c.map_.num_entries = int(c.num_entries.Load())
+	c.map_.grow()
+	c.publish_geometry()
+}
+
+func (c *Concurrent_DAM[KT, VT]) Enquire_Number_Of_Buckets() uint64 {
+	return c.num_buckets_m1.Load() + 1
+}
+
+// Returns the number of entries currently stored in the map.
+//
+// - NOTE: With concurrent writers, the result may be outdated as soon as it is returned.
+func (c *Concurrent_DAM[KT, VT]) Len() int {
+	return int(c.num_entries.Load())
+}
+
+// Get a value from the map, read-locking only the stripe of the key.
+func (c *Concurrent_DAM[KT, VT]) Get(key KT) (VT, bool) {
+	s, buck := c.lock_bucket_of(key, false)
+	defer s.mu.RUnlock()
+
+	for i := 0; i < len(buck.entries); i++ {
+		if buck.entries[i].key == key {
+			return buck.entries[i].value, true
+		}
+	}
+
+	var zero VT
+	return zero, false
+}
+
+// Set a key-value pair in the map, write-locking only the stripe of the key.
+func (c *Concurrent_DAM[KT, VT]) Set(key KT, value VT) {
+	s, buck := c.lock_bucket_of(key, true)
+
+	for i := 0; i < len(buck.entries); i++ {
+		if buck.entries[i].key == key {
+			buck.entries[i].value = value
+			s.mu.Unlock()
+			return
+		}
+	}
+
+	c.insert_and_unlock(s, buck, key, value)
+}
+
+// Append a new entry to `buck`, which must be the bucket of `key` with its stripe write-locked,
+// and return the new number of entries to pass to `grow_if_needed` once the stripe is unlocked.
+func (c *Concurrent_DAM[KT, VT]) insert(buck *bucket[KT, VT], key KT, value VT) int64 {
+	buck.entries = append(buck.entries, t_bucket_entry[KT, VT]{key: key, value: value})
+	return c.num_entries.Add(1)
+}
+
+// Grow if `num_entries` is past the threshold.
+//
+// - WARNING: Must be called with no stripe locked, since `grow` locks all of them.
+func (c *Concurrent_DAM[KT, VT]) grow_if_needed(num_entries int64) {
+	if num_entries > c.grow_at_num_entries.Load() {
+		c.grow()
+	}
+}
+
+// Same as `insert`, then unlock `s` and grow if needed.
+func (c *Concurrent_DAM[KT, VT]) insert_and_unlock(s *t_stripe, buck *bucket[KT, VT], key KT, value VT) {
+	num_entries := c.insert(buck, key, value)
+	s.mu.Unlock()
+	c.grow_if_needed(num_entries)
+}
+
+// Delete an entry from the map and return a boolean indicating whether the entry was found.
+func (c *Concurrent_DAM[KT, VT]) Delete(key KT) bool {
+	s, buck := c.lock_bucket_of(key, true)
+	defer s.mu.Unlock()
+
+	if !buck.remove(key, c.map_.delete_strategy) {
+		return false
+	}
+	c.num_entries.Add(-1)
+	return true
+}
+
+// Remove every entry from the map, with every stripe locked.
+func (c *Concurrent_DAM[KT, VT]) Clear() {
+	c.lock_all()
+	defer c.unlock_all()
+
+	c.map_.Clear()
+	c.num_entries.Store(0)
+}
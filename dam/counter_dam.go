@@ -0,0 +1,350 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import (
+	"container/heap"
+	"iter"
+	"math"
+	"slices"
+	"sync/atomic"
+)
+
+// How a counter behaves when an `Add` would take it past the range of a uint64.
+type T_Overflow_Mode uint8
+
+const (
+	// Counters wrap around, like plain uint64 arithmetic.
+	OVERFLOW_MODE__WRAP T_Overflow_Mode = iota
+	// Counters stick at 0 or at `math.MaxUint64`.
+	OVERFLOW_MODE__SATURATE
+)
+
+// Returns `count + delta` following `mode`.
+//
+//go:inline
+func add_to_count(count uint64, delta int64, mode T_Overflow_Mode) uint64 {
+	sum := count + uint64(delta)
+	if mode == OVERFLOW_MODE__SATURATE {
+		if delta >= 0 && sum < count {
+			return math.MaxUint64
+		}
+		if delta < 0 && sum > count {
+			return 0
+		}
+	}
+	return sum
+}
+
+// Returns the sum of every count following `mode`.
+func sum_counts(counts iter.Seq[uint64], mode T_Overflow_Mode) uint64 {
+	sum := uint64(0)
+	for count := range counts {
+		sum += count
+		if mode == OVERFLOW_MODE__SATURATE && sum < count {
+			return math.MaxUint64
+		}
+	}
+	return sum
+}
+
+// A key and its count, as returned by `Top_K`.
+type T_Count[KT I_Positive_Integer] struct {
+	Key   KT
+	Count uint64
+}
+
+// Ranks higher counts first, then lower keys first so that the order is deterministic.
+//
+//go:inline
+func ranks_before[KT I_Positive_Integer](a T_Count[KT], b T_Count[KT]) bool {
+	return a.Count > b.Count || (a.Count == b.Count && a.Key < b.Key)
+}
+
+// A min-heap on rank, so the root is the lowest ranked of the counts kept so far.
+type t_count_heap[KT I_Positive_Integer] []T_Count[KT]
+
+func (h t_count_heap[KT]) Len() int           { return len(h) }
+func (h t_count_heap[KT]) Less(i, j int) bool { return ranks_before(h[j], h[i]) }
+func (h t_count_heap[KT]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *t_count_heap[KT]) Push(x any)        { *h = append(*h, x.(T_Count[KT])) }
+func (h *t_count_heap[KT]) Pop() any {
+	last := (*h)[len(*h)-1]
+	*h = (*h)[:len(*h)-1]
+	return last
+}
+
+// Returns the `n` highest ranked counts, highest first, keeping only `n` of them in memory at a time.
+func top_k_counts[KT I_Positive_Integer](counts iter.Seq2[KT, uint64], n int) []T_Count[KT] {
+	if n <= 0 {
+		return nil
+	}
+
+	h := make(t_count_heap[KT], 0, min(n, 1024))
+	for key, count := range counts {
+		c := T_Count[KT]{key, count}
+		if len(h) < n {
+			heap.Push(&h, c)
+		} else if ranks_before(c, h[0]) {
+			h[0] = c
+			heap.Fix(&h, 0)
+		}
+	}
+
+	slices.SortFunc(h, func(a T_Count[KT], b T_Count[KT]) int {
+		if ranks_before(a, b) {
+			return -1
+		}
+		return 1
+	})
+	return h
+}
+
+// A `DAM[KT, uint64]` used as a table of counters.
+//
+// Missing keys count as 0, and `Add` finds or inserts its key with a single scan of the bucket.
+type Counter_DAM[KT I_Positive_Integer] struct {
+	map_          *DAM[KT, uint64]
+	overflow_mode T_Overflow_Mode
+}
+
+// Accepts the same options as `New`.
+//
+// - NOTE: Panics if `overflow_mode` or the options are invalid.
+func New_Counter_DAM[KT I_Positive_Integer](
+	expected_num_inputs KT,
+	overflow_mode T_Overflow_Mode,
+	options ...T_Option[KT, uint64],
+) *Counter_DAM[KT] {
+	if overflow_mode > OVERFLOW_MODE__SATURATE {
+		panic("Invalid overflow mode.")
+	}
+
+	return &Counter_DAM[KT]{
+		map_:          New(expected_num_inputs, options...),
+		overflow_mode: overflow_mode,
+	}
+}
+
+func (m *Counter_DAM[KT]) Enquire_Number_Of_Buckets() uint64 {
+	return m.map_.Enquire_Number_Of_Buckets()
+}
+
+// Returns the number of counters currently stored in the map.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *Counter_DAM[KT]) Len() int {
+	return m.map_.Len()
+}
+
+// Remove every counter from the map, keeping the allocated buckets.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *Counter_DAM[KT]) Clear() {
+	m.map_.Clear()
+}
+
+// Add `delta` to the counter of `key` and return the new count.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+// - NOTE: A counter that reaches 0 is kept, use `Delete` to remove it.
+func (m *Counter_DAM[KT]) Add(key KT, delta int64) uint64 {
+	buck := m.map_.bucket_of(key)
+	if i := buck.index_of(key); i >= 0 {
+		count := add_to_count(buck.entries[i].value, delta, m.overflow_mode)
+		buck.entries[i].value = count
+		return count
+	}
+
+	count := add_to_count(0, delta, m.overflow_mode)
+	m.map_.insert(buck, key, count)
+	return count
+}
+
+// Same as `Add(key, 1)`.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+//go:inline
+func (m *Counter_DAM[KT]) Inc(key KT) uint64 {
+	return m.Add(key, 1)
+}
+
+// Returns the count of `key`, 0 if it is missing.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+//go:inline
+func (m *Counter_DAM[KT]) Get(key KT) uint64 {
+	count, _ := m.map_.Get(key)
+	return count
+}
+
+// Delete a counter from the map and return a boolean indicating whether it was found.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+//go:inline
+func (m *Counter_DAM[KT]) Delete(key KT) bool {
+	return m.map_.Delete(key)
+}
+
+// Returns the sum of every counter, wrapping or saturating like the counters themselves.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+// - NOTE: Visits every counter.
+func (m *Counter_DAM[KT]) Sum() uint64 {
+	return sum_counts(m.map_.Values(), m.overflow_mode)
+}
+
+// Returns the `n` highest counters, highest first, ties broken by the lower key.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+// - NOTE: Visits every counter, in O(Len() * log(n)).
+func (m *Counter_DAM[KT]) Top_K(n int) []T_Count[KT] {
+	return top_k_counts(m.map_.All(), n)
+}
+
+// Returns an iterator over every key and its count, see `DAM.All` for the mutation rules.
+//
+// - NOTE: Calling `Add` on a key that already exists counts as a `Set`.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *Counter_DAM[KT]) All() iter.Seq2[KT, uint64] {
+	return m.map_.All()
+}
+
+// A `Counter_DAM` that is safe for concurrent use, built on a `Concurrent_DAM`.
+//
+// Adding to an existing counter only read-locks its stripe and updates the count with an atomic instruction,
+// so goroutines aggregating into the same stripe do not block each other. Inserting a new key write-locks the stripe.
+type Concurrent_Counter_DAM[KT I_Positive_Integer] struct {
+	map_          *Concurrent_DAM[KT, uint64]
+	overflow_mode T_Overflow_Mode
+}
+
+// Accepts the same arguments as `New_Concurrent_DAM`.
+//
+// - NOTE: Panics if `overflow_mode` or the options are invalid.
+func New_Concurrent_Counter_DAM[KT I_Positive_Integer](
+	expected_num_inputs KT,
+	num_stripes int,
+	overflow_mode T_Overflow_Mode,
+	options ...T_Option[KT, uint64],
+) *Concurrent_Counter_DAM[KT] {
+	if overflow_mode > OVERFLOW_MODE__SATURATE {
+		panic("Invalid overflow mode.")
+	}
+
+	return &Concurrent_Counter_DAM[KT]{
+		map_:          New_Concurrent_DAM(expected_num_inputs, num_stripes, options...),
+		overflow_mode: overflow_mode,
+	}
+}
+
+func (c *Concurrent_Counter_DAM[KT]) Enquire_Number_Of_Buckets() uint64 {
+	return c.map_.Enquire_Number_Of_Buckets()
+}
+
+// Returns the number of counters currently stored in the map.
+//
+// - NOTE: With concurrent writers, the result may be outdated as soon as it is returned.
+func (c *Concurrent_Counter_DAM[KT]) Len() int {
+	return c.map_.Len()
+}
+
+// Remove every counter from the map, with every stripe locked.
+func (c *Concurrent_Counter_DAM[KT]) Clear() {
+	c.map_.Clear()
+}
+
+// Atomically add `delta` to `*count` and return the new count.
+//
+//go:inline
+func (c *Concurrent_Counter_DAM[KT]) add_atomic(count *uint64, delta int64) uint64 {
+	if c.overflow_mode == OVERFLOW_MODE__WRAP {
+		return atomic.AddUint64(count, uint64(delta))
+	}
+	for {
+		old := atomic.LoadUint64(count)
+		new := add_to_count(old, delta, c.overflow_mode)
+		if atomic.CompareAndSwapUint64(count, old, new) {
+			return new
+		}
+	}
+}
+
+// Atomically add `delta` to the counter of `key` and return the new count.
+//
+// - NOTE: A counter that reaches 0 is kept, use `Delete` to remove it.
+func (c *Concurrent_Counter_DAM[KT]) Add(key KT, delta int64) uint64 {
+	s, buck := c.map_.lock_bucket_of(key, false)
+	if i := buck.index_of(key); i >= 0 {
+		count := c.add_atomic(&buck.entries[i].value, delta)
+		s.mu.RUnlock()
+		return count
+	}
+	s.mu.RUnlock()
+
+	// Another goroutine may insert the key before the write lock is held...
+	s, buck = c.map_.lock_bucket_of(key, true)
+	if i := buck.index_of(key); i >= 0 {
+		count := add_to_count(buck.entries[i].value, delta, c.overflow_mode)
+		buck.entries[i].value = count
+		s.mu.Unlock()
+		return count
+	}
+
+	count := add_to_count(0, delta, c.overflow_mode)
+	c.map_.insert_and_unlock(s, buck, key, count)
+	return count
+}
+
+// Same as `Add(key, 1)`.
+//
+//go:inline
+func (c *Concurrent_Counter_DAM[KT]) Inc(key KT) uint64 {
+	return c.Add(key, 1)
+}
+
+// Returns the count of `key`, 0 if it is missing.
+func (c *Concurrent_Counter_DAM[KT]) Get(key KT) uint64 {
+	s, buck := c.map_.lock_bucket_of(key, false)
+	defer s.mu.RUnlock()
+
+	if i := buck.index_of(key); i >= 0 {
+		return atomic.LoadUint64(&buck.entries[i].value)
+	}
+	return 0
+}
+
+// Delete a counter from the map and return a boolean indicating whether it was found.
+func (c *Concurrent_Counter_DAM[KT]) Delete(key KT) bool {
+	return c.map_.Delete(key)
+}
+
+// Returns the sum of every counter, wrapping or saturating like the counters themselves.
+//
+// - NOTE: Locks every stripe while visiting every counter, so the sum is a consistent snapshot.
+func (c *Concurrent_Counter_DAM[KT]) Sum() uint64 {
+	c.map_.lock_all()
+	defer c.map_.unlock_all()
+
+	return sum_counts(c.map_.map_.Values(), c.overflow_mode)
+}
+
+// Returns the `n` highest counters, highest first, ties broken by the lower key.
+//
+// - NOTE: Locks every stripe while visiting every counter, so the result is a consistent snapshot.
+func (c *Concurrent_Counter_DAM[KT]) Top_K(n int) []T_Count[KT] {
+	c.map_.lock_all()
+	defer c.map_.unlock_all()
+
+	return top_k_counts(c.map_.map_.All(), n)
+}
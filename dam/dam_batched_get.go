@@ -0,0 +1,75 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+// The most keys `Get_Many` looks up at once.
+const max_get_batch_size = 64
+
+// Look up every key of `keys`, storing the value in `out[i]` and whether it was found in `found[i]`.
+//
+// With `With_Experimental_Batched_Gets`, a random lookup spends most of its time waiting on two cache misses,
+// one for the bucket and one for its entries. Each batch is therefore processed in three passes:
+//
+// - The bucket of every key is loaded.
+//
+// - The first entry of every bucket is compared, which is where most keys are found.
+//
+// - The rest of the buckets are scanned for the keys not found yet.
+//
+// The loads within a pass do not depend on each other, so the CPU can keep many misses in flight.
+//
+// - NOTE: Falls back to a loop of `Get` while an incremental resize is in progress.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) Get_Many(keys []KT, out []VT, found []bool) {
+	if len(out) < len(keys) || len(found) < len(keys) {
+		panic("`out` and `found` must be at least as long as `keys`.")
+	}
+
+	if m.get_batch_size == 0 || m.old_buckets != nil {
+		for i, key := range keys {
+			out[i], found[i] = m.Get(key)
+		}
+		return
+	}
+
+	var batch [max_get_batch_size][]t_bucket_entry[KT, VT]
+	for start := 0; start < len(keys); start += m.get_batch_size {
+		end := min(start+m.get_batch_size, len(keys))
+		batch_keys := keys[start:end]
+		batch_out := out[start:end]
+		batch_found := found[start:end]
+		entries := batch[:len(batch_keys)]
+
+		// Load the buckets...
+		for i, key := range batch_keys {
+			entries[i] = m.buckets[m.bucket_index(key)].entries
+		}
+
+		// Compare the first entries...
+		for i, key := range batch_keys {
+			batch_found[i] = len(entries[i]) != 0 && entries[i][0].key == key
+			if batch_found[i] {
+				batch_out[i] = entries[i][0].value
+			}
+		}
+
+		// Scan the rest...
+		for i, key := range batch_keys {
+			if batch_found[i] {
+				continue
+			}
+			var zero VT
+			batch_out[i] = zero
+			for j := 1; j < len(entries[i]); j++ {
+				if entries[i][j].key == key {
+					batch_out[i], batch_found[i] = entries[i][j].value, true
+					break
+				}
+			}
+		}
+	}
+}
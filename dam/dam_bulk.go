@@ -0,0 +1,299 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Build a map holding `keys[i]` mapped to `values[i]`, with the same options as `New`.
+//
+// The number of keys per bucket is counted first, so the entries of all buckets are allocated at once
+// and the map never grows while loading.
+//
+// With `keys_are_unique`, the buckets are not scanned for duplicates before appending.
+//
+// - WARNING: When `keys_are_unique` is set but a key is repeated, the map holds it twice and `Len` counts both.
+//
+// - NOTE: Panics if the options are invalid or `keys` and `values` differ in length.
+func Build_From[KT I_Positive_Integer, VT any](
+	keys []KT,
+	values []VT,
+	keys_are_unique bool,
+	options ...T_Option[KT, VT],
+) *DAM[KT, VT] {
+	if len(keys) != len(values) {
+		panic("`keys` and `values` must have the same length.")
+	}
+
+	m, err := new_DAM(uint64(len(keys)), false, options)
+	if err != nil {
+		panic(err)
+	}
+	m.bulk_set(keys, values, keys_are_unique, 1)
+
+	return m
+}
+
+// Same as `Build_From`, but spread over `num_workers` goroutines, `runtime.GOMAXPROCS(0)` when 0 or less.
+//
+// The input is split into chunks that are grouped by bucket range side by side, then every worker
+// fills its own run of bucket ranges, so the workers never touch the same bucket and need no locking.
+//
+// - NOTE: A hash function given with `With_Hash_Func` is called from all workers at once.
+func Build_From_Parallel[KT I_Positive_Integer, VT any](
+	keys []KT,
+	values []VT,
+	keys_are_unique bool,
+	num_workers int,
+	options ...T_Option[KT, VT],
+) *DAM[KT, VT] {
+	if len(keys) != len(values) {
+		panic("`keys` and `values` must have the same length.")
+	}
+	if num_workers <= 0 {
+		num_workers = runtime.GOMAXPROCS(0)
+	}
+
+	m, err := new_DAM(uint64(len(keys)), false, options)
+	if err != nil {
+		panic(err)
+	}
+	m.bulk_set(keys, values, keys_are_unique, num_workers)
+
+	return m
+}
+
+// Set `keys[i]` to `values[i]` for every i, as if `Set` was called for each of them in order.
+//
+// The map grows at most once, to the size needed for all the new keys, and the buckets that
+// receive keys are reallocated once, together.
+//
+// - NOTE: An incremental resize in progress is finished first.
+//
+// - NOTE: While an iterator is active, this is exactly a loop of `Set`, see `DAM.All` for what is allowed.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) Set_Many(keys []KT, values []VT) {
+	if len(keys) != len(values) {
+		panic("`keys` and `values` must have the same length.")
+	}
+
+	// Growing up front would be a structural change even if every key is already present...
+	if m.num_active_iterators != 0 {
+		m.set_each(keys, values)
+		return
+	}
+
+	m.reserve(m.num_entries + len(keys))
+	m.bulk_set(keys, values, false, 1)
+}
+
+// Grow once to the geometry needed for `num_entries`, instead of doubling repeatedly on the way there.
+func (m *DAM[KT, VT]) reserve(num_entries int) {
+	if m.auto_growth_disabled || num_entries <= m.grow_at_num_entries {
+		return
+	}
+
+	num_buckets := num_buckets_for(uint64(num_entries), m.target_entries_per_bucket, m.using_range_reduction)
+	num_buckets = min(max(num_buckets, uint64(len(m.buckets))*2), max_num_buckets[KT]())
+	m.resize(num_buckets)
+}
+
+// New entries are written one range of `1 << bulk_set_range_shift` buckets at a time, so that the
+// writes of a range stay in cache instead of landing all over the map.
+const bulk_set_range_shift = 11
+
+// Calls `f(w)` for every worker on its own goroutine and waits for all of them.
+func run_workers(num_workers int, f func(w int)) {
+	if num_workers == 1 {
+		f(0)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(num_workers)
+	for w := 0; w < num_workers; w++ {
+		go func() {
+			defer wg.Done()
+			f(w)
+		}()
+	}
+	wg.Wait()
+}
+
+// The slow path of `bulk_set`, one `Set` per key.
+func (m *DAM[KT, VT]) set_each(keys []KT, values []VT) {
+	for i, key := range keys {
+		m.Set(key, values[i])
+	}
+}
+
+// Bucket ranges are independent, so with `num_workers` above 1 every step below runs on disjoint
+// parts of the input and of the buckets, without any locking.
+func (m *DAM[KT, VT]) bulk_set(keys []KT, values []VT, keys_are_unique bool, num_workers int) {
+	// Running iterators must keep seeing the same entries, so nothing may be migrated or moved...
+	if m.num_active_iterators != 0 {
+		m.set_each(keys, values)
+		return
+	}
+
+	m.Finish_Resize()
+
+	// Counting does not pay off for a handful of keys...
+	if len(m.buckets) == 1 || len(keys) < len(m.buckets)/4 {
+		m.set_each(keys, values)
+		return
+	}
+
+	num_ranges := len(m.buckets)>>bulk_set_range_shift + 1
+	num_workers = max(min(num_workers, num_ranges, len(keys)), 1)
+	chunk_size := (len(keys) + num_workers - 1) / num_workers
+	chunk := func(w int) (int, int) {
+		return min(w*chunk_size, len(keys)), min((w+1)*chunk_size, len(keys))
+	}
+
+	// Count the keys of every bucket range in every chunk of the input...
+	next := make([][]int, num_workers)
+	run_workers(num_workers, func(w int) {
+		next[w] = make([]int, num_ranges)
+		lo, hi := chunk(w)
+		for _, key := range keys[lo:hi] {
+			next[w][m.bucket_index(key)>>bulk_set_range_shift]++
+		}
+	})
+
+	// Turn the counts into offsets, ordered by range and then by chunk so that the input order is kept...
+	range_start := make([]int, num_ranges+1)
+	offset := 0
+	for r := 0; r < num_ranges; r++ {
+		range_start[r] = offset
+		for w := range next {
+			c := next[w][r]
+			next[w][r] = offset
+			offset += c
+		}
+	}
+	range_start[num_ranges] = offset
+
+	// Group the new entries by bucket range, which streams through memory...
+	grouped := make([]t_bucket_entry[KT, VT], len(keys))
+	run_workers(num_workers, func(w int) {
+		lo, hi := chunk(w)
+		for i := lo; i < hi; i++ {
+			r := m.bucket_index(keys[i]) >> bulk_set_range_shift
+			grouped[next[w][r]] = t_bucket_entry[KT, VT]{key: keys[i], value: values[i]}
+			next[w][r]++
+		}
+	})
+
+	// Fill the buckets, giving every worker about the same number of entries...
+	range_lo := make([]int, num_workers+1)
+	for w := 1; w < num_workers; w++ {
+		r := range_lo[w-1]
+		for r < num_ranges && range_start[r] < w*len(keys)/num_workers {
+			r++
+		}
+		range_lo[w] = r
+	}
+	range_lo[num_workers] = num_ranges
+	num_added := make([]int, num_workers)
+	run_workers(num_workers, func(w int) {
+		r_lo, r_hi := range_lo[w], range_lo[w+1]
+		num_added[w] = m.fill_buckets(
+			grouped[range_start[r_lo]:range_start[r_hi]],
+			min(r_lo<<bulk_set_range_shift, len(m.buckets)),
+			min(r_hi<<bulk_set_range_shift, len(m.buckets)),
+			keys_are_unique,
+		)
+	})
+
+	total_added := 0
+	for _, n := range num_added {
+		total_added += n
+	}
+	if total_added != 0 {
+		m.num_entries += total_added
+		m.num_structural_changes++
+	}
+	if m.num_entries > m.grow_at_num_entries {
+		m.grow()
+	}
+}
+
+// Add `entries` to the buckets `lo` to `hi`, which they must all belong to, and return how many were new.
+func (m *DAM[KT, VT]) fill_buckets(entries []t_bucket_entry[KT, VT], lo int, hi int, keys_are_unique bool) int {
+	buckets := m.buckets[lo:hi]
+
+	counts := make([]int, len(buckets))
+	for _, e := range entries {
+		counts[int(m.bucket_index(e.key))-lo]++
+	}
+
+	// Reallocate the buckets that are short on room, carving all of them out of a single allocation.
+	// Unique keys are written by position instead, so every bucket that receives keys is reallocated...
+	needs_room := func(i int, c int) bool {
+		spare := cap(buckets[i].entries) - len(buckets[i].entries)
+		return c != 0 && (keys_are_unique || c > spare)
+	}
+	total := 0
+	for i, c := range counts {
+		if needs_room(i, c) {
+			total += len(buckets[i].entries) + c
+		}
+	}
+	all_entries := make([]t_bucket_entry[KT, VT], total)
+	offset := 0
+	for i, c := range counts {
+		if !needs_room(i, c) {
+			continue
+		}
+		buck := &buckets[i]
+		n := len(buck.entries)
+		reallocated := all_entries[offset : offset+n : offset+n+c]
+		copy(reallocated, buck.entries)
+		buck.entries = reallocated
+
+		// From here on, `counts` holds the next free slot of every reallocated bucket, used for unique keys...
+		counts[i] = offset + n
+		offset += n + c
+	}
+
+	if keys_are_unique {
+		// Every key is new, so it goes straight into the next free slot without touching the bucket...
+		for _, e := range entries {
+			i := int(m.bucket_index(e.key)) - lo
+			all_entries[counts[i]] = e
+			counts[i]++
+		}
+		for i, next := range counts {
+			if next != 0 {
+				buckets[i].entries = buckets[i].entries[:cap(buckets[i].entries)]
+			}
+		}
+		return len(entries)
+	}
+
+	num_added := 0
+	for _, e := range entries {
+		buck := &buckets[int(m.bucket_index(e.key))-lo]
+
+		found := false
+		for j := range buck.entries {
+			if buck.entries[j].key == e.key {
+				buck.entries[j].value = e.value
+				found = true
+				break
+			}
+		}
+		if !found {
+			buck.entries = append(buck.entries, e)
+			num_added++
+		}
+	}
+	return num_added
+}
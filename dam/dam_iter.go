@@ -0,0 +1,74 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import "iter"
+
+// Returns an iterator over every key-value pair in the map, in bucket order.
+//
+// Mutation rules while iterating:
+//
+// - Calling `Set` on a key that already exists is allowed, the new value is visible if that entry has not been visited yet.
+//
+// - Inserting a new key, calling `Delete` or calling `Clear` is NOT allowed and will panic on the next step of the iteration.
+//
+// - An incremental resize is paused until the iteration ends.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) All() iter.Seq2[KT, VT] {
+	return func(yield func(KT, VT) bool) {
+		expected_num_structural_changes := m.num_structural_changes
+
+		m.num_active_iterators++
+		defer func() {
+			m.num_active_iterators--
+		}()
+
+		for _, buckets := range m.live_buckets() {
+			for i := range buckets {
+				entries := buckets[i].entries
+				for j := 0; j < len(entries); j++ {
+					if !yield(entries[j].key, entries[j].value) {
+						return
+					}
+					if m.num_structural_changes != expected_num_structural_changes {
+						panic("DAM was structurally modified during iteration.")
+					}
+				}
+			}
+		}
+	}
+}
+
+// Returns an iterator over every key in the map, in bucket order.
+//
+// The same mutation rules as `All` apply.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) Keys() iter.Seq[KT] {
+	return func(yield func(KT) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over every value in the map, in bucket order.
+//
+// The same mutation rules as `All` apply.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) Values() iter.Seq[VT] {
+	return func(yield func(VT) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -5,8 +5,11 @@
 
 package dam
 
+import "math/bits"
+
+// Any unsigned integer type, including defined types such as `type User_ID uint64`.
 type I_Positive_Integer interface {
-	uint8 | uint16 | uint32 | uint64
+	~uint8 | ~uint16 | ~uint32 | ~uint64
 }
 
 type t_bucket_entry[KT I_Positive_Integer, VT any] struct {
@@ -19,90 +22,274 @@ type bucket[KT I_Positive_Integer, VT any] struct {
 }
 
 // Super-Fast Direct-Access Map.
+//
+// Every key, including 0, can be stored: buckets track their own length,
+// so no key value is reserved to mark an empty slot.
 type DAM[KT I_Positive_Integer, VT any] struct {
 	buckets        []bucket[KT, VT]
-	num_buckets_m1 KT
+	num_buckets_m1 uint64
+
+	// Backs `buckets` while the map is small enough to be a single bucket.
+	small_map_bucket [1]bucket[KT, VT]
+	// How many entries that single bucket holds before the map spreads out, see `small_map_capacity`.
+	single_bucket_capacity uint64
+
+	// Pick buckets with a multiply-shift instead of a mask, so any bucket count works.
+	using_range_reduction bool
+
+	// Picked once in `New`: the raw key by default, otherwise a built-in mixer or the user's hash func.
+	hash_func func(KT) uint64
+
+	delete_strategy T_Delete_Strategy
+
+	num_entries int
 
-	users_chosen_hash_func func(KT) uint64
-	using_users_hash_func  bool
+	// Bumped by every insert, delete, clear and resize so iterators can detect them.
+	num_structural_changes uint64
+
+	// The average bucket length the geometry aims for, we grow once it is exceeded.
+	target_entries_per_bucket uint64
+	auto_growth_disabled      bool
+	grow_at_num_entries       int
+
+	// The capacity new buckets are allocated with.
+	initial_bucket_capacity uint64
+
+	// While an incremental resize is in progress, `old_buckets[migrate_cursor:]` still hold
+	// every key whose old bucket has not been migrated yet, all other keys live in `buckets`.
+	old_buckets              []bucket[KT, VT]
+	old_num_buckets_m1       uint64
+	migrate_cursor           uint64
+	migrate_buckets_per_step uint64
+
+	// Migration is paused while iterators are running so they never see an entry twice.
+	num_active_iterators int
+
+	// The number of keys `Get_Many` looks up at once, 0 when batched gets are disabled.
+	get_batch_size int
 
 	profile T_Performance_Profile
 }
 
+// - NOTE: Panics if the options are invalid, use `New_With_Error` when they come from configuration.
 func New[KT I_Positive_Integer, VT any](
 	expected_num_inputs KT,
 	options ...T_Option[KT, VT],
 ) *DAM[KT, VT] {
-	expected_num_inputs = next_power_of_two(expected_num_inputs)
+	m, err := New_With_Error(expected_num_inputs, options...)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Same as `New`, but returns a `*T_Invalid_Option_Error` or `*T_Conflicting_Options_Error` instead of panicking.
+func New_With_Error[KT I_Positive_Integer, VT any](
+	expected_num_inputs KT,
+	options ...T_Option[KT, VT],
+) (*DAM[KT, VT], error) {
+	// NOTE: We size in uint64 since the rounded up count may not fit in KT, e.g. 200 rounds up to 256 for uint8.
+	return new_DAM(uint64(expected_num_inputs), true, options)
+}
+
+// Without `preallocate`, the buckets start without entries so that a bulk load can size each of them exactly.
+func new_DAM[KT I_Positive_Integer, VT any](
+	expected uint64,
+	preallocate bool,
+	options []T_Option[KT, VT],
+) (*DAM[KT, VT], error) {
+	inst, err := new_DAM_config(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Allocate buckets...
+	num_buckets := inst.num_buckets_for_expected(expected)
+	if num_buckets == 1 {
+		// A map this small is fastest as a single linear array, kept inline to save an allocation...
+		inst.small_map_bucket[0].entries = make([]t_bucket_entry[KT, VT], 0, expected)
+		inst.buckets = inst.small_map_bucket[:]
+	} else if preallocate {
+		inst.buckets = make_buckets[KT, VT](num_buckets, inst.initial_bucket_capacity)
+	} else {
+		inst.buckets = make([]bucket[KT, VT], num_buckets)
+	}
+	inst.num_buckets_m1 = num_buckets - 1
+
+	inst.update_growth_threshold()
+
+	return inst, nil
+}
+
+// Returns a map configured by `options`, but without any buckets.
+//
+// Types built on the configuration of a `DAM`, such as `DAM_Set`, allocate their own buckets.
+func new_DAM_config[KT I_Positive_Integer, VT any](options []T_Option[KT, VT]) (*DAM[KT, VT], error) {
+	if err := validate_options(options); err != nil {
+		return nil, err
+	}
 
 	profile := PERFORMANCE_PROFILE__SAVE_MEMORY
+	using_range_reduction := false
+	var geometry *T_Bucket_Geometry
 	for _, opt := range options {
-		if opt.t == OPTION_TYPE__WITH_PERFORMANCE_PROFILE {
+		switch opt.t {
+		case OPTION_TYPE__WITH_BUCKET_GEOMETRY:
+			g := opt.other.(T_Bucket_Geometry)
+			geometry = &g
+		case OPTION_TYPE__WITH_PERFORMANCE_PROFILE:
 			profile = opt.other.(T_Performance_Profile)
+		case OPTION_TYPE__WITH_RANGE_REDUCTION:
+			using_range_reduction = opt.other.(bool)
 		}
 	}
 
-	var num_buckets KT
+	var target_entries_per_bucket uint64
 	switch profile {
 	case PERFORMANCE_PROFILE__FAST:
-		num_buckets = expected_num_inputs / 2
+		target_entries_per_bucket = 2
 	case PERFORMANCE_PROFILE__NORMAL:
-		num_buckets = expected_num_inputs / 4
+		target_entries_per_bucket = 4
 	case PERFORMANCE_PROFILE__SAVE_MEMORY:
-		num_buckets = expected_num_inputs / 8
-	default:
-		panic("Invalid performance profile.")
+		target_entries_per_bucket = 8
 	}
-
-	if num_buckets%2 != 0 {
-		panic("numBuckets should be a multiple of 2.")
-	}
-
-	// Allocate buckets...
-	num_buckets_runtime := any(num_buckets).(uint64)
-	buckets := make([]bucket[KT, VT], num_buckets_runtime)
-	estimated_num_entries_per_bucket := expected_num_inputs / num_buckets
-	for i := uint64(0); i < num_buckets_runtime; i++ {
-		b := bucket[KT, VT]{
-			entries: make([]t_bucket_entry[KT, VT], 0, estimated_num_entries_per_bucket),
-		}
-		buckets[i] = b
+	initial_bucket_capacity := target_entries_per_bucket
+	if geometry != nil {
+		target_entries_per_bucket = geometry.Entries_Per_Bucket
+		initial_bucket_capacity = geometry.Initial_Bucket_Capacity
 	}
 
 	// Instantiate...
 	inst := DAM[KT, VT]{
-		buckets:        buckets,
-		num_buckets_m1: num_buckets - 1,
-		profile:        profile,
+		hash_func:                 identity_hash[KT],
+		using_range_reduction:     using_range_reduction,
+		target_entries_per_bucket: target_entries_per_bucket,
+		initial_bucket_capacity:   initial_bucket_capacity,
+		single_bucket_capacity:    small_map_capacity[KT, VT](),
+		profile:                   profile,
 	}
 
 	// Apply options...
 	for _, opt := range options {
-		if opt.t != OPTION_TYPE__WITH_PERFORMANCE_PROFILE {
+		if opt.f != nil {
 			opt.f(&inst)
 		}
 	}
 
-	return &inst
+	return &inst, nil
+}
+
+// Returns how many buckets a new map sized for `expected` entries starts with.
+func (m *DAM[KT, VT]) num_buckets_for_expected(expected uint64) uint64 {
+	if expected <= m.single_bucket_capacity {
+		return 1
+	}
+	num_buckets := num_buckets_for(expected, m.target_entries_per_bucket, m.using_range_reduction)
+	return min(num_buckets, max_num_buckets[KT]())
+}
+
+func make_buckets[KT I_Positive_Integer, VT any](num_buckets uint64, capacity uint64) []bucket[KT, VT] {
+	buckets := make([]bucket[KT, VT], num_buckets)
+	for i := uint64(0); i < num_buckets; i++ {
+		buckets[i] = bucket[KT, VT]{
+			entries: make([]t_bucket_entry[KT, VT], 0, capacity),
+		}
+	}
+	return buckets
 }
 
-func (m *DAM[KT, VT]) Enquire_Number_Of_Buckets() KT {
+// NOTE: Returns a uint64 since the number of buckets may not fit in KT, e.g. 256 buckets for uint8 keys.
+func (m *DAM[KT, VT]) Enquire_Number_Of_Buckets() uint64 {
 	return m.num_buckets_m1 + 1
 }
 
+// Returns the geometry chosen through `With_Bucket_Geometry`, or derived from the performance profile.
+func (m *DAM[KT, VT]) Enquire_Bucket_Geometry() T_Bucket_Geometry {
+	return T_Bucket_Geometry{
+		Entries_Per_Bucket:      m.target_entries_per_bucket,
+		Initial_Bucket_Capacity: m.initial_bucket_capacity,
+	}
+}
+
+// Returns the index of the bucket the key belongs to.
+//
+//go:inline
+func (m *DAM[KT, VT]) bucket_index(key KT) uint64 {
+	return m.reduce(m.hash(key), m.num_buckets_m1)
+}
+
+// The hash func is picked in `New`, so a lookup never has to test which kind of hash the map uses.
+//
+//go:inline
+func (m *DAM[KT, VT]) hash(key KT) uint64 {
+	return m.hash_func(key)
+}
+
+// The default hash, the raw key.
+func identity_hash[KT I_Positive_Integer](key KT) uint64 {
+	return uint64(key)
+}
+
+// Maps a hash onto one of `num_buckets_m1 + 1` buckets.
+//
+// With range reduction, the hash is first multiplied by 2^64 divided by the golden ratio so that
+// small or sequential hashes still reach the high bits, then Lemire's multiply-shift keeps the
+// high 64 bits of `h * num_buckets`, which is always below `num_buckets`.
+//
+//go:inline
+func (m *DAM[KT, VT]) reduce(h uint64, num_buckets_m1 uint64) uint64 {
+	if m.using_range_reduction {
+		hi, _ := bits.Mul64(h*fibonacci_multiplier, num_buckets_m1+1)
+		return hi
+	}
+	return h & num_buckets_m1
+}
+
+// Returns the bucket the key lives in, or would be inserted into.
+//
+//go:inline
+func (m *DAM[KT, VT]) bucket_of(key KT) *bucket[KT, VT] {
+	if m.old_buckets != nil {
+		return m.migrating_bucket_of(key)
+	}
+	return &m.buckets[m.bucket_index(key)]
+}
+
+// Returns the number of entries currently stored in the map.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) Len() int {
+	return m.num_entries
+}
+
+// Remove every entry from the map.
+// The buckets keep their allocated backing arrays so that refilling the map does not allocate.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) Clear() {
+	// Whatever has not been migrated yet is simply dropped...
+	m.old_buckets = nil
+	m.migrate_cursor = 0
+
+	for i := range m.buckets {
+		buck := &m.buckets[i]
+		clear(buck.entries)
+		buck.entries = buck.entries[:0]
+	}
+	m.num_entries = 0
+	m.num_structural_changes++
+}
+
 // Set a key-value pair in the map.
+// Doubles the number of buckets once the average bucket length exceeds the profile's target,
+// unless disabled with `With_Auto_Growth`, see `With_Incremental_Resize` to spread that cost out.
 // Will panic if something goes wrong.
 //
 // - WARNING: This function is NOT thread-safe.
 //
 //go:inline
 func (m *DAM[KT, VT]) Set(key KT, value VT) {
-	if key == 0 {
-		panic("Key cannot be 0.")
-	}
-
-	index := key & m.num_buckets_m1
-	buck := &m.buckets[index]
+	buck := m.bucket_of(key)
 
 	for i := 0; i < len(buck.entries); i++ {
 		if buck.entries[i].key == key {
@@ -111,7 +298,7 @@ func (m *DAM[KT, VT]) Set(key KT, value VT) {
 		}
 	}
 
-	buck.entries = append(buck.entries, t_bucket_entry[KT, VT]{key: key, value: value})
+	m.insert(buck, key, value)
 }
 
 // The runtime overhead was too much:
@@ -163,14 +350,9 @@ func (m *DAM[KT, VT]) Set(key KT, value VT) {
 //
 // - WARNING: This function is NOT thread-safe.
 //
-// - NOTE: Remember that keys cannot be 0.
-//
-// - NOTE: This function will not check if the key is 0.
-//
 //go:inline
 func (m *DAM[KT, VT]) Get(key KT) (VT, bool) {
-	// NOTE: Keeping value type here improves performance since we do not modify the value.
-	buck := m.buckets[key&m.num_buckets_m1]
+	buck := m.bucket_of(key)
 
 	for i := 0; i < len(buck.entries); i += 1 {
 		if buck.entries[i].key == key {
@@ -184,32 +366,60 @@ func (m *DAM[KT, VT]) Get(key KT) (VT, bool) {
 
 // Delete an entry from the map and return a boolean indicating whether the entry was found.
 //
-// - WARNING: This function is NOT thread-safe.
+// The freed slot stays within the bucket's capacity, so later `Set` calls into the same bucket
+// will reuse it without allocating.
 //
-// - NOTE: Remember that keys cannot be 0.
+// - WARNING: This function is NOT thread-safe.
 //
-// - NOTE: This function will not check if the key is 0.
+// - NOTE: The order of the remaining entries in the bucket depends on the chosen `T_Delete_Strategy`.
 //
 //go:inline
 func (m *DAM[KT, VT]) Delete(key KT) bool {
-	// index := key & m.num_buckets_m1
-	// buck := &m.buckets[index]
-
-	// loc := -1
-
-	// for i := 0; i < len(buck.entries); i++ {
-	// 	if buck.entries[i].key == uint64(key) {
-	// 		loc = i
-	// 		break
-	// 	}
-	// }
-
-	// // Rearrange the entire slice...
-	// if loc == -1 {
-	// 	return false
-	// }
-
-	// buck.entries = append(buck.entries[:loc], buck.entries[loc+1:]...)
-	// return true
-	panic("Not implemented.")
+	if !m.bucket_of(key).remove(key, m.delete_strategy) {
+		return false
+	}
+
+	m.num_entries--
+	m.num_structural_changes++
+	return true
+}
+
+// Remove the entry of `key` from the bucket and return whether it was there.
+//
+//go:inline
+func (b *bucket[KT, VT]) remove(key KT, strategy T_Delete_Strategy) bool {
+	i := b.index_of(key)
+	if i < 0 {
+		return false
+	}
+	b.remove_at(i, strategy)
+	return true
+}
+
+// Returns the index of the entry of `key` in the bucket, or -1 when it is missing.
+//
+//go:inline
+func (b *bucket[KT, VT]) index_of(key KT) int {
+	for i := 0; i < len(b.entries); i++ {
+		if b.entries[i].key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Remove the entry at index `i` from the bucket.
+//
+//go:inline
+func (b *bucket[KT, VT]) remove_at(i int, strategy T_Delete_Strategy) {
+	last := len(b.entries) - 1
+	if strategy == DELETE_STRATEGY__ORDERED_SHIFT {
+		copy(b.entries[i:], b.entries[i+1:])
+	} else {
+		b.entries[i] = b.entries[last]
+	}
+
+	// Zero the vacated slot so we do not keep the old value alive...
+	b.entries[last] = t_bucket_entry[KT, VT]{}
+	b.entries = b.entries[:last]
 }
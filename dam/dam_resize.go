@@ -0,0 +1,158 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import "math"
+
+// Recompute how many entries the map can hold before the average bucket
+// length exceeds `target_entries_per_bucket`.
+func (m *DAM[KT, VT]) update_growth_threshold() {
+	if m.auto_growth_disabled {
+		m.grow_at_num_entries = math.MaxInt
+		return
+	}
+
+	num_buckets := m.num_buckets_m1 + 1
+	if num_buckets == 1 {
+		m.grow_at_num_entries = int(max(m.single_bucket_capacity, m.target_entries_per_bucket))
+		return
+	}
+
+	// Doubling again would give us more buckets than there are keys...
+	if num_buckets > max_num_buckets[KT]()/2 || num_buckets > math.MaxInt/m.target_entries_per_bucket {
+		m.grow_at_num_entries = math.MaxInt
+		return
+	}
+	m.grow_at_num_entries = int(num_buckets * m.target_entries_per_bucket)
+}
+
+// Double the number of buckets.
+//
+// A small map that outgrows its single bucket jumps straight to the full bucket geometry instead.
+func (m *DAM[KT, VT]) grow() {
+	m.resize(m.next_num_buckets())
+}
+
+// Returns the number of buckets the next call to `grow` resizes to.
+func (m *DAM[KT, VT]) next_num_buckets() uint64 {
+	num_buckets := (m.num_buckets_m1 + 1) * 2
+	num_buckets = max(num_buckets, num_buckets_for(uint64(m.num_entries), m.target_entries_per_bucket, m.using_range_reduction))
+	return min(num_buckets, max_num_buckets[KT]())
+}
+
+// Change the number of buckets.
+//
+// By default every entry is rehashed right away, which costs time proportional to the number of entries.
+// With `With_Incremental_Resize`, the old buckets are kept around and migrated a few at a time instead.
+func (m *DAM[KT, VT]) resize(num_buckets uint64) {
+	// A previous resize must be done before we start the next one,
+	// active iterators will panic anyway since this is a structural change...
+	m.finish_migration()
+
+	old_buckets := m.buckets
+	old_num_buckets_m1 := m.num_buckets_m1
+
+	m.num_buckets_m1 = num_buckets - 1
+	m.num_structural_changes++
+	m.update_growth_threshold()
+
+	if m.migrate_buckets_per_step == 0 {
+		m.buckets = make_buckets[KT, VT](num_buckets, m.initial_bucket_capacity)
+		for i := range old_buckets {
+			m.migrate_bucket(&old_buckets[i])
+		}
+		return
+	}
+
+	// Preallocating every bucket would be a stop-the-world cost of its own,
+	// so the new buckets allocate their entries lazily as they are filled...
+	m.buckets = make([]bucket[KT, VT], num_buckets)
+	m.old_buckets = old_buckets
+	m.old_num_buckets_m1 = old_num_buckets_m1
+	m.migrate_cursor = 0
+}
+
+// Move every entry of an old bucket into the new buckets.
+func (m *DAM[KT, VT]) migrate_bucket(old *bucket[KT, VT]) {
+	for _, e := range old.entries {
+		buck := &m.buckets[m.bucket_index(e.key)]
+		buck.entries = append(buck.entries, e)
+	}
+	old.entries = nil
+}
+
+// Migrate the next few old buckets, as configured by `With_Incremental_Resize`.
+func (m *DAM[KT, VT]) migrate_step() {
+	if m.num_active_iterators != 0 {
+		return
+	}
+
+	end := min(m.migrate_cursor+m.migrate_buckets_per_step, uint64(len(m.old_buckets)))
+	for ; m.migrate_cursor < end; m.migrate_cursor++ {
+		m.migrate_bucket(&m.old_buckets[m.migrate_cursor])
+	}
+
+	if m.migrate_cursor == uint64(len(m.old_buckets)) {
+		m.old_buckets = nil
+		m.migrate_cursor = 0
+	}
+}
+
+// The slow path of `bucket_of` while an incremental resize is in progress.
+func (m *DAM[KT, VT]) migrating_bucket_of(key KT) *bucket[KT, VT] {
+	m.migrate_step()
+
+	if m.old_buckets != nil {
+		old_index := m.reduce(m.hash(key), m.old_num_buckets_m1)
+		if old_index >= m.migrate_cursor {
+			return &m.old_buckets[old_index]
+		}
+	}
+
+	return &m.buckets[m.bucket_index(key)]
+}
+
+// Returns the bucket arrays that currently hold entries, the not yet migrated old buckets first.
+func (m *DAM[KT, VT]) live_buckets() [2][]bucket[KT, VT] {
+	var old []bucket[KT, VT]
+	if m.old_buckets != nil {
+		old = m.old_buckets[m.migrate_cursor:]
+	}
+	return [2][]bucket[KT, VT]{old, m.buckets}
+}
+
+// Returns whether an incremental resize is still in progress.
+func (m *DAM[KT, VT]) Enquire_Is_Resizing() bool {
+	return m.old_buckets != nil
+}
+
+// Migrate every remaining old bucket of an incremental resize right now.
+//
+// Call this off the hot path to avoid paying for the migration later on.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+// - NOTE: Does nothing if no resize is in progress, or while an iterator is active,
+// since moving entries would make the iterator visit them twice.
+func (m *DAM[KT, VT]) Finish_Resize() {
+	if m.num_active_iterators != 0 {
+		return
+	}
+	m.finish_migration()
+}
+
+// Migrate every remaining old bucket, iterators or not.
+func (m *DAM[KT, VT]) finish_migration() {
+	if m.old_buckets == nil {
+		return
+	}
+
+	for ; m.migrate_cursor < uint64(len(m.old_buckets)); m.migrate_cursor++ {
+		m.migrate_bucket(&m.old_buckets[m.migrate_cursor])
+	}
+	m.old_buckets = nil
+	m.migrate_cursor = 0
+}
@@ -0,0 +1,184 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+// Read-modify-write operations, each one scans the bucket of its key only once.
+
+// Append a new entry to `buck`, which must be the bucket of `key`, and grow if needed.
+func (m *DAM[KT, VT]) insert(buck *bucket[KT, VT], key KT, value VT) {
+	buck.entries = append(buck.entries, t_bucket_entry[KT, VT]{key: key, value: value})
+	m.num_entries++
+	m.num_structural_changes++
+
+	if m.num_entries > m.grow_at_num_entries {
+		m.grow()
+	}
+}
+
+// Returns the value of `key` and true if it is present, otherwise sets it to `value` and returns `value` and false.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) Get_Or_Set(key KT, value VT) (VT, bool) {
+	buck := m.bucket_of(key)
+	if i := buck.index_of(key); i >= 0 {
+		return buck.entries[i].value, true
+	}
+
+	m.insert(buck, key, value)
+	return value, false
+}
+
+// Set `key` to `value` and return the previous value and whether there was one.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) Swap(key KT, value VT) (VT, bool) {
+	buck := m.bucket_of(key)
+	if i := buck.index_of(key); i >= 0 {
+		previous := buck.entries[i].value
+		buck.entries[i].value = value
+		return previous, true
+	}
+
+	m.insert(buck, key, value)
+	var zero VT
+	return zero, false
+}
+
+// Replace the entry of `key` with the result of `f`, which receives the current value and whether there is one.
+//
+// When `f` returns true the key is set to the returned value, otherwise the key is deleted.
+// Returns the new value and whether the key is present afterwards.
+//
+// - WARNING: This function is NOT thread-safe, and `f` must not use the map.
+func (m *DAM[KT, VT]) Compute(key KT, f func(old VT, exists bool) (VT, bool)) (VT, bool) {
+	buck := m.bucket_of(key)
+	i := buck.index_of(key)
+
+	var old VT
+	if i >= 0 {
+		old = buck.entries[i].value
+	}
+	value, keep := f(old, i >= 0)
+
+	switch {
+	case keep && i >= 0:
+		buck.entries[i].value = value
+	case keep:
+		m.insert(buck, key, value)
+	case i >= 0:
+		buck.remove_at(i, m.delete_strategy)
+		m.num_entries--
+		m.num_structural_changes++
+	}
+
+	if !keep {
+		var zero VT
+		return zero, false
+	}
+	return value, true
+}
+
+// Set `key` to `new` only if it is present with the value `old`, and return whether it was set.
+//
+// A function rather than a method, since it needs a comparable `VT`.
+//
+// - WARNING: This function is NOT thread-safe.
+func Compare_And_Swap[KT I_Positive_Integer, VT comparable](m *DAM[KT, VT], key KT, old VT, new VT) bool {
+	buck := m.bucket_of(key)
+	if i := buck.index_of(key); i >= 0 && buck.entries[i].value == old {
+		buck.entries[i].value = new
+		return true
+	}
+	return false
+}
+
+// Same as `DAM.Get_Or_Set`, atomically for the key.
+func (c *Concurrent_DAM[KT, VT]) Get_Or_Set(key KT, value VT) (VT, bool) {
+	s, buck := c.lock_bucket_of(key, true)
+	if i := buck.index_of(key); i >= 0 {
+		actual := buck.entries[i].value
+		s.mu.Unlock()
+		return actual, true
+	}
+
+	c.insert_and_unlock(s, buck, key, value)
+	return value, false
+}
+
+// Same as `DAM.Swap`, atomically for the key.
+func (c *Concurrent_DAM[KT, VT]) Swap(key KT, value VT) (VT, bool) {
+	s, buck := c.lock_bucket_of(key, true)
+	if i := buck.index_of(key); i >= 0 {
+		previous := buck.entries[i].value
+		buck.entries[i].value = value
+		s.mu.Unlock()
+		return previous, true
+	}
+
+	c.insert_and_unlock(s, buck, key, value)
+	var zero VT
+	return zero, false
+}
+
+// Same as `DAM.Compute`, atomically for the key.
+//
+// - WARNING: `f` runs with the stripe of the key locked, so it must not use the map and should be quick.
+//
+// - NOTE: If `f` panics, the stripe is unlocked and the map is left unchanged.
+func (c *Concurrent_DAM[KT, VT]) Compute(key KT, f func(old VT, exists bool) (VT, bool)) (VT, bool) {
+	value, keep, num_entries := c.compute_locked(key, f)
+	c.grow_if_needed(num_entries)
+
+	if !keep {
+		var zero VT
+		return zero, false
+	}
+	return value, true
+}
+
+// The part of `Compute` that runs with the stripe locked, unlocking it with a deferred call so that
+// a panicking `f` cannot leave it locked.
+//
+// Returns the number of entries after an insert, 0 otherwise.
+func (c *Concurrent_DAM[KT, VT]) compute_locked(key KT, f func(old VT, exists bool) (VT, bool)) (VT, bool, int64) {
+	s, buck := c.lock_bucket_of(key, true)
+	defer s.mu.Unlock()
+
+	i := buck.index_of(key)
+	var old VT
+	if i >= 0 {
+		old = buck.entries[i].value
+	}
+	value, keep := f(old, i >= 0)
+
+	switch {
+	case keep && i >= 0:
+		buck.entries[i].value = value
+	case keep:
+		return value, true, c.insert(buck, key, value)
+	case i >= 0:
+		buck.remove_at(i, c.map_.delete_strategy)
+		c.num_entries.Add(-1)
+	}
+	return value, keep, 0
+}
+
+// Same as `Compare_And_Swap`, atomically for the key.
+func Concurrent_Compare_And_Swap[KT I_Positive_Integer, VT comparable](
+	c *Concurrent_DAM[KT, VT],
+	key KT,
+	old VT,
+	new VT,
+) bool {
+	s, buck := c.lock_bucket_of(key, true)
+	defer s.mu.Unlock()
+
+	if i := buck.index_of(key); i >= 0 && buck.entries[i].value == old {
+		buck.entries[i].value = new
+		return true
+	}
+	return false
+}
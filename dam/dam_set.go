@@ -0,0 +1,271 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import "iter"
+
+type t_set_bucket[KT I_Positive_Integer] struct {
+	keys []KT
+}
+
+func make_set_buckets[KT I_Positive_Integer](num_buckets uint64, capacity uint64) []t_set_bucket[KT] {
+	buckets := make([]t_set_bucket[KT], num_buckets)
+	for i := uint64(0); i < num_buckets; i++ {
+		buckets[i] = t_set_bucket[KT]{
+			keys: make([]KT, 0, capacity),
+		}
+	}
+	return buckets
+}
+
+// A set of integers laid out like a `DAM`, but storing only the keys.
+//
+// A `DAM[KT, struct{}]` pays for a padded value slot in every entry, e.g. 16 bytes instead of 8 for uint64 keys,
+// since Go pads a trailing zero-size field. Here a bucket is a plain slice of keys.
+type DAM_Set[KT I_Positive_Integer] struct {
+	// Holds the hashing and growth configuration and the counters, it has no buckets of its own.
+	config *DAM[KT, struct{}]
+
+	buckets []t_set_bucket[KT]
+}
+
+// Accepts the same options as `New`, except `With_Incremental_Resize`.
+//
+// - NOTE: Panics if the options are invalid.
+func New_DAM_Set[KT I_Positive_Integer](
+	expected_num_inputs KT,
+	options ...T_Option[KT, struct{}],
+) *DAM_Set[KT] {
+	for _, opt := range options {
+		if opt.t == OPTION_TYPE__WITH_INCREMENTAL_RESIZE {
+			if step, _ := opt.other.(uint64); step != 0 {
+				panic(&T_Invalid_Option_Error{opt.t, "`DAM_Set` always rehashes every key at once"})
+			}
+		}
+	}
+
+	config, err := new_DAM_config(options)
+	if err != nil {
+		panic(err)
+	}
+	// Without a value slot, a single bucket holds more keys in the same number of bytes...
+	config.single_bucket_capacity = small_set_capacity[KT]()
+
+	inst := DAM_Set[KT]{
+		config: config,
+	}
+	inst.alloc_buckets(uint64(expected_num_inputs))
+
+	return &inst
+}
+
+// Replace the buckets with empty ones sized for `expected` keys.
+func (s *DAM_Set[KT]) alloc_buckets(expected uint64) {
+	num_buckets := s.config.num_buckets_for_expected(expected)
+	s.config.num_buckets_m1 = num_buckets - 1
+	s.config.update_growth_threshold()
+
+	if num_buckets == 1 {
+		// Like a small `DAM`, a single bucket holds exactly what is expected...
+		s.buckets = make_set_buckets[KT](1, expected)
+	} else {
+		s.buckets = make_set_buckets[KT](num_buckets, s.config.initial_bucket_capacity)
+	}
+}
+
+// Returns an empty set with the same configuration as `s`, sized for `expected` keys.
+func (s *DAM_Set[KT]) new_like(expected uint64) *DAM_Set[KT] {
+	config := *s.config
+	config.num_entries = 0
+	config.num_structural_changes = 0
+	config.num_active_iterators = 0
+
+	inst := DAM_Set[KT]{
+		config: &config,
+	}
+	inst.alloc_buckets(expected)
+
+	return &inst
+}
+
+// NOTE: Returns a uint64 since the number of buckets may not fit in KT.
+func (s *DAM_Set[KT]) Enquire_Number_Of_Buckets() uint64 {
+	return s.config.num_buckets_m1 + 1
+}
+
+// Returns the number of keys currently stored in the set.
+//
+// - WARNING: This function is NOT thread-safe.
+func (s *DAM_Set[KT]) Len() int {
+	return s.config.num_entries
+}
+
+// Remove every key from the set, keeping the allocated buckets.
+//
+// - WARNING: This function is NOT thread-safe.
+func (s *DAM_Set[KT]) Clear() {
+	for i := range s.buckets {
+		s.buckets[i].keys = s.buckets[i].keys[:0]
+	}
+	s.config.num_entries = 0
+	s.config.num_structural_changes++
+}
+
+//go:inline
+func (s *DAM_Set[KT]) bucket_of(key KT) *t_set_bucket[KT] {
+	return &s.buckets[s.config.bucket_index(key)]
+}
+
+// Double the number of buckets and rehash every key.
+func (s *DAM_Set[KT]) grow() {
+	num_buckets := s.config.next_num_buckets()
+	s.config.num_buckets_m1 = num_buckets - 1
+	s.config.num_structural_changes++
+	s.config.update_growth_threshold()
+
+	old_buckets := s.buckets
+	s.buckets = make_set_buckets[KT](num_buckets, s.config.initial_bucket_capacity)
+	for i := range old_buckets {
+		for _, key := range old_buckets[i].keys {
+			buck := s.bucket_of(key)
+			buck.keys = append(buck.keys, key)
+		}
+	}
+}
+
+// Add `key` to the set and return whether it was missing.
+//
+// - WARNING: This function is NOT thread-safe.
+func (s *DAM_Set[KT]) Add(key KT) bool {
+	buck := s.bucket_of(key)
+	for i := 0; i < len(buck.keys); i++ {
+		if buck.keys[i] == key {
+			return false
+		}
+	}
+
+	buck.keys = append(buck.keys, key)
+	s.config.num_entries++
+	s.config.num_structural_changes++
+
+	if s.config.num_entries > s.config.grow_at_num_entries {
+		s.grow()
+	}
+	return true
+}
+
+// Returns whether `key` is in the set.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+//go:inline
+func (s *DAM_Set[KT]) Has(key KT) bool {
+	buck := s.bucket_of(key)
+	for i := 0; i < len(buck.keys); i++ {
+		if buck.keys[i] == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove `key` from the set and return whether it was there.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+// - NOTE: The order of the remaining keys in the bucket depends on the chosen `T_Delete_Strategy`.
+func (s *DAM_Set[KT]) Remove(key KT) bool {
+	buck := s.bucket_of(key)
+	for i := 0; i < len(buck.keys); i++ {
+		if buck.keys[i] != key {
+			continue
+		}
+
+		last := len(buck.keys) - 1
+		if s.config.delete_strategy == DELETE_STRATEGY__ORDERED_SHIFT {
+			copy(buck.keys[i:], buck.keys[i+1:])
+		} else {
+			buck.keys[i] = buck.keys[last]
+		}
+		buck.keys = buck.keys[:last]
+
+		s.config.num_entries--
+		s.config.num_structural_changes++
+		return true
+	}
+	return false
+}
+
+// Returns an iterator over every key in the set, in bucket order.
+//
+// Adding a key that is already present is allowed, but adding a new key, calling `Remove` or calling `Clear`
+// while iterating is NOT allowed and will panic on the next step of the iteration.
+//
+// - WARNING: This function is NOT thread-safe.
+func (s *DAM_Set[KT]) All() iter.Seq[KT] {
+	return func(yield func(KT) bool) {
+		expected_num_structural_changes := s.config.num_structural_changes
+
+		for i := range s.buckets {
+			keys := s.buckets[i].keys
+			for j := 0; j < len(keys); j++ {
+				if !yield(keys[j]) {
+					return
+				}
+				if s.config.num_structural_changes != expected_num_structural_changes {
+					panic("DAM_Set was structurally modified during iteration.")
+				}
+			}
+		}
+	}
+}
+
+// Returns a new set holding the keys of both `s` and `other`, configured like `s`.
+//
+// - WARNING: This function is NOT thread-safe.
+func (s *DAM_Set[KT]) Union(other *DAM_Set[KT]) *DAM_Set[KT] {
+	result := s.new_like(uint64(s.Len() + other.Len()))
+	for key := range s.All() {
+		result.Add(key)
+	}
+	for key := range other.All() {
+		result.Add(key)
+	}
+	return result
+}
+
+// Returns a new set holding the keys that are in both `s` and `other`, configured like `s`.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+// - NOTE: Visits the smaller of the two sets only.
+func (s *DAM_Set[KT]) Intersection(other *DAM_Set[KT]) *DAM_Set[KT] {
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+
+	result := s.new_like(uint64(small.Len()))
+	for key := range small.All() {
+		if large.Has(key) {
+			result.Add(key)
+		}
+	}
+	return result
+}
+
+// Returns a new set holding the keys of `s` that are not in `other`, configured like `s`.
+//
+// - WARNING: This function is NOT thread-safe.
+func (s *DAM_Set[KT]) Difference(other *DAM_Set[KT]) *DAM_Set[KT] {
+	result := s.new_like(uint64(s.Len()))
+	for key := range s.All() {
+		if !other.Has(key) {
+			result.Add(key)
+		}
+	}
+	return result
+}
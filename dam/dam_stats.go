@@ -0,0 +1,70 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import "unsafe"
+
+// A snapshot of how entries are spread across the buckets of a `DAM`.
+//
+// Use it to check whether the chosen `T_Performance_Profile` matches the real workload.
+type T_Stats struct {
+	Num_Buckets uint64
+	Num_Entries uint64
+
+	Min_Entries_Per_Bucket  uint64
+	Max_Entries_Per_Bucket  uint64
+	Mean_Entries_Per_Bucket float64
+
+	// `Bucket_Length_Histogram[i]` is the number of buckets holding exactly `i` entries.
+	Bucket_Length_Histogram []uint64
+
+	// Bytes reserved by the backing arrays of all buckets.
+	Capacity_Bytes uint64
+	// Bytes of those backing arrays that hold live entries.
+	Used_Bytes uint64
+}
+
+// Walk every bucket and collect a `T_Stats` snapshot.
+//
+// This is O(number of buckets), so do not call it on a hot path.
+//
+// - NOTE: While an incremental resize is in progress, the not yet migrated old buckets are counted as well.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *DAM[KT, VT]) Stats() T_Stats {
+	entry_size := uint64(unsafe.Sizeof(t_bucket_entry[KT, VT]{}))
+
+	s := T_Stats{
+		Num_Entries:            uint64(m.num_entries),
+		Min_Entries_Per_Bucket: ^uint64(0),
+	}
+
+	for _, buckets := range m.live_buckets() {
+		s.Num_Buckets += uint64(len(buckets))
+
+		for i := range buckets {
+			n := uint64(len(buckets[i].entries))
+			s.Min_Entries_Per_Bucket = min(s.Min_Entries_Per_Bucket, n)
+			s.Max_Entries_Per_Bucket = max(s.Max_Entries_Per_Bucket, n)
+
+			for uint64(len(s.Bucket_Length_Histogram)) <= n {
+				s.Bucket_Length_Histogram = append(s.Bucket_Length_Histogram, 0)
+			}
+			s.Bucket_Length_Histogram[n]++
+
+			s.Capacity_Bytes += uint64(cap(buckets[i].entries)) * entry_size
+			s.Used_Bytes += n * entry_size
+		}
+	}
+
+	if s.Num_Buckets == 0 {
+		s.Min_Entries_Per_Bucket = 0
+	} else {
+		s.Mean_Entries_Per_Bucket = float64(s.Num_Entries) / float64(s.Num_Buckets)
+	}
+
+	return s
+}
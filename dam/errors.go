@@ -0,0 +1,56 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import "strconv"
+
+// Returned by `New_With_Error` when an option is invalid on its own, e.g. an unknown performance profile.
+type T_Invalid_Option_Error struct {
+	Option T_Option_Type
+	Reason string
+}
+
+func (e *T_Invalid_Option_Error) Error() string {
+	return "dam: invalid `" + e.Option.String() + "` option: " + e.Reason
+}
+
+// Returned by `New_With_Error` when two options cannot be used together, e.g. a hash func and a hash mixer.
+//
+// - NOTE: `Option` and `Conflicts_With` are equal when the same option is given more than once.
+type T_Conflicting_Options_Error struct {
+	Option         T_Option_Type
+	Conflicts_With T_Option_Type
+	Reason         string
+}
+
+func (e *T_Conflicting_Options_Error) Error() string {
+	return "dam: `" + e.Option.String() + "` conflicts with `" + e.Conflicts_With.String() + "`: " + e.Reason
+}
+
+func (t T_Option_Type) String() string {
+	switch t {
+	case OPTION_TYPE__WITH_HASH_FUNC:
+		return "With_Hash_Func"
+	case OPTION_TYPE__WITH_PERFORMANCE_PROFILE:
+		return "With_Performance_Profile"
+	case OPTION_TYPE__WITH_EXPERIMENTAL_BATCHED_GETS:
+		return "With_Experimental_Batched_Gets"
+	case OPTION_TYPE__WITH_DELETE_STRATEGY:
+		return "With_Delete_Strategy"
+	case OPTION_TYPE__WITH_HASH_MIXER:
+		return "With_Hash_Mixer"
+	case OPTION_TYPE__WITH_AUTO_GROWTH:
+		return "With_Auto_Growth"
+	case OPTION_TYPE__WITH_INCREMENTAL_RESIZE:
+		return "With_Incremental_Resize"
+	case OPTION_TYPE__WITH_RANGE_REDUCTION:
+		return "With_Range_Reduction"
+	case OPTION_TYPE__WITH_BUCKET_GEOMETRY:
+		return "With_Bucket_Geometry"
+	default:
+		return "T_Option_Type(" + strconv.Itoa(int(t)) + ")"
+	}
+}
@@ -0,0 +1,124 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import "strconv"
+
+// Sequential keys spread perfectly when we just mask off the low bits, but strided keys
+// (multiples of 1024, snowflake IDs, timestamps) share their low bits and pile into a handful of buckets.
+// The mixers below scramble every bit of the key into the low bits before we mask.
+
+const (
+	// 2^64 / golden ratio.
+	fibonacci_multiplier = 0x9e3779b97f4a7c15
+
+	murmur3_c1 = 0xff51afd7ed558ccd
+	murmur3_c2 = 0xc4ceb9fe1a85ec53
+
+	splitmix64_gamma = 0x9e3779b97f4a7c15
+	splitmix64_c1    = 0xbf58476d1ce4e5b9
+	splitmix64_c2    = 0x94d049bb133111eb
+)
+
+type T_Hash_Mixer uint8
+
+const (
+	// Use the key as is, which is what a `DAM` does when no hash is configured.
+	HASH_MIXER__IDENTITY T_Hash_Mixer = iota
+	// Fibonacci multiplicative hashing.
+	HASH_MIXER__FIBONACCI
+	// The 64-bit finalizer of murmur3.
+	HASH_MIXER__MURMUR3
+	// The output function of splitmix64.
+	HASH_MIXER__SPLITMIX64
+	// The murmur3 finalizer applied to the key mixed with a seed, see `With_Seeded_Hash_Mixer`.
+	HASH_MIXER__SEEDED
+)
+
+func (mixer T_Hash_Mixer) String() string {
+	switch mixer {
+	case HASH_MIXER__IDENTITY:
+		return "Identity"
+	case HASH_MIXER__FIBONACCI:
+		return "Fibonacci"
+	case HASH_MIXER__MURMUR3:
+		return "Murmur3"
+	case HASH_MIXER__SPLITMIX64:
+		return "Splitmix64"
+	case HASH_MIXER__SEEDED:
+		return "Seeded"
+	default:
+		return "T_Hash_Mixer(" + strconv.Itoa(int(mixer)) + ")"
+	}
+}
+
+// Returns the key unchanged.
+//
+//go:inline
+func Mix_Identity(k uint64) uint64 {
+	return k
+}
+
+// Multiplies the key by 2^64 divided by the golden ratio.
+//
+// Only the high bits of the product are well mixed, so they are folded into the low bits
+// that the bucket mask looks at.
+//
+//go:inline
+func Mix_Fibonacci(k uint64) uint64 {
+	h := k * fibonacci_multiplier
+	return h ^ (h >> 32)
+}
+
+// The 64-bit finalizer (`fmix64`) of murmur3.
+//
+//go:inline
+func Mix_Murmur3(k uint64) uint64 {
+	k ^= k >> 33
+	k *= murmur3_c1
+	k ^= k >> 33
+	k *= murmur3_c2
+	k ^= k >> 33
+	return k
+}
+
+// The output function of splitmix64, where the key takes the place of the generator's state.
+//
+//go:inline
+func Mix_Splitmix64(k uint64) uint64 {
+	k += splitmix64_gamma
+	k = (k ^ (k >> 30)) * splitmix64_c1
+	k = (k ^ (k >> 27)) * splitmix64_c2
+	return k ^ (k >> 31)
+}
+
+// The murmur3 finalizer applied to the key mixed with a seed.
+//
+// Different seeds give unrelated bucket layouts for the same keys.
+//
+//go:inline
+func Mix_Seeded(k uint64, seed uint64) uint64 {
+	return Mix_Murmur3(k ^ Mix_Splitmix64(seed))
+}
+
+// Returns the named mixer as a plain function.
+// Will panic if the mixer is unknown or needs a seed.
+func Hash_Mixer_Func(mixer T_Hash_Mixer) func(uint64) uint64 {
+	switch mixer {
+	case HASH_MIXER__IDENTITY:
+		return Mix_Identity
+	case HASH_MIXER__FIBONACCI:
+		return Mix_Fibonacci
+	case HASH_MIXER__MURMUR3:
+		return Mix_Murmur3
+	case HASH_MIXER__SPLITMIX64:
+		return Mix_Splitmix64
+	case HASH_MIXER__SEEDED:
+		panic("The seeded mixer needs a seed, use `Mix_Seeded` instead.")
+	default:
+		panic("Invalid hash mixer.")
+	}
+}
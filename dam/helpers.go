@@ -5,6 +5,8 @@
 
 package dam
 
+import "unsafe"
+
 func _inner__next_power_of_two__uint64(n uint64) uint64 {
 	n--
 	n |= n >> 1
@@ -17,47 +19,51 @@ func _inner__next_power_of_two__uint64(n uint64) uint64 {
 	return n
 }
 
-func _inner__next_power_of_two__uint32(n uint32) uint32 {
-	n--
-	n |= n >> 1
-	n |= n >> 2
-	n |= n >> 4
-	n |= n >> 8
-	n |= n >> 16
-	n++
-	return n
+// Maps whose entries fit in this many bytes are kept as a single linear array, i.e. one bucket.
+const small_map_max_bytes = 128
+
+// Returns how many buckets are needed to hold `num_entries` at `entries_per_bucket`.
+//
+// Without range reduction the result is a power of two, either way it is at least 1.
+func num_buckets_for(num_entries uint64, entries_per_bucket uint64, using_range_reduction bool) uint64 {
+	if !using_range_reduction {
+		num_entries = _inner__next_power_of_two__uint64(num_entries)
+	}
+	num_buckets := max(num_entries/entries_per_bucket, 1)
+
+	// The bucket mask only reaches every bucket of a power of two count,
+	// which dividing by an `entries_per_bucket` such as 3 would break...
+	if !using_range_reduction {
+		num_buckets = _inner__next_power_of_two__uint64(num_buckets)
+	}
+	return num_buckets
 }
 
-func _inner__next_power_of_two__uint16(n uint16) uint16 {
-	n--
-	n |= n >> 1
-	n |= n >> 2
-	n |= n >> 4
-	n |= n >> 8
-	n++
-	return n
+// Returns how many entries fit in `small_map_max_bytes`, but at least 1.
+func small_map_capacity[KT I_Positive_Integer, VT any]() uint64 {
+	entry_size := uint64(unsafe.Sizeof(t_bucket_entry[KT, VT]{}))
+	return max(small_map_max_bytes/entry_size, 1)
 }
 
-func _inner__next_power_of_two__uint8(n uint8) uint8 {
-	n--
-	n |= n >> 1
-	n |= n >> 2
-	n |= n >> 4
-	n++
-	return n
+// Same as `small_map_capacity`, for a `DAM_Set` which stores only the keys.
+func small_set_capacity[KT I_Positive_Integer]() uint64 {
+	key_size := uint64(unsafe.Sizeof(*new(KT)))
+	return max(small_map_max_bytes/key_size, 1)
+}
+
+// Returns the width of KT in bits.
+func key_bits[KT I_Positive_Integer]() uint64 {
+	var k KT
+	return uint64(unsafe.Sizeof(k)) * 8
 }
 
-func next_power_of_two[KT I_Positive_Integer](n KT) KT {
-	switch any(n).(type) {
-	case uint64:
-		return any(_inner__next_power_of_two__uint64(any(n).(uint64))).(KT)
-	case uint32:
-		return any(_inner__next_power_of_two__uint32(any(n).(uint32))).(KT)
-	case uint16:
-		return any(_inner__next_power_of_two__uint16(any(n).(uint16))).(KT)
-	case uint8:
-		return any(_inner__next_power_of_two__uint8(any(n).(uint8))).(KT)
-	default:
-		panic("Unsupported type.")
+// Returns the number of distinct keys of type KT, which is also the most buckets that can ever be useful.
+//
+// - NOTE: Saturates at the largest uint64 for 64-bit keys.
+func max_num_buckets[KT I_Positive_Integer]() uint64 {
+	b := key_bits[KT]()
+	if b >= 64 {
+		return ^uint64(0)
 	}
+	return 1 << b
 }
@@ -5,12 +5,20 @@
 
 package dam
 
+import "strconv"
+
 type T_Option_Type uint8
 
 const (
 	OPTION_TYPE__WITH_HASH_FUNC T_Option_Type = iota
 	OPTION_TYPE__WITH_PERFORMANCE_PROFILE
 	OPTION_TYPE__WITH_EXPERIMENTAL_BATCHED_GETS
+	OPTION_TYPE__WITH_DELETE_STRATEGY
+	OPTION_TYPE__WITH_HASH_MIXER
+	OPTION_TYPE__WITH_AUTO_GROWTH
+	OPTION_TYPE__WITH_INCREMENTAL_RESIZE
+	OPTION_TYPE__WITH_RANGE_REDUCTION
+	OPTION_TYPE__WITH_BUCKET_GEOMETRY
 )
 
 type T_Option[KT I_Positive_Integer, VT any] struct {
@@ -21,10 +29,70 @@ type T_Option[KT I_Positive_Integer, VT any] struct {
 
 func With_Hash_Func[KT I_Positive_Integer, VT any](f func(KT) uint64) T_Option[KT, VT] {
 	return T_Option[KT, VT]{
-		t: OPTION_TYPE__WITH_HASH_FUNC,
+		t:     OPTION_TYPE__WITH_HASH_FUNC,
+		other: f,
+		f: func(m *DAM[KT, VT]) {
+			m.hash_func = f
+		},
+	}
+}
+
+// Use one of the built-in mixers from `hash_mixers.go` to pick buckets.
+//
+// `HASH_MIXER__IDENTITY` is the same as the default, the raw key is used as the hash.
+//
+// - NOTE: Use `With_Seeded_Hash_Mixer` for `HASH_MIXER__SEEDED`, `New_With_Error` rejects it here since it needs a seed.
+func With_Hash_Mixer[KT I_Positive_Integer, VT any](mixer T_Hash_Mixer) T_Option[KT, VT] {
+	var f func(KT) uint64
+	switch mixer {
+	case HASH_MIXER__IDENTITY:
+		f = identity_hash[KT]
+	case HASH_MIXER__FIBONACCI:
+		f = func(k KT) uint64 { return Mix_Fibonacci(uint64(k)) }
+	case HASH_MIXER__MURMUR3:
+		f = func(k KT) uint64 { return Mix_Murmur3(uint64(k)) }
+	case HASH_MIXER__SPLITMIX64:
+		f = func(k KT) uint64 { return Mix_Splitmix64(uint64(k)) }
+	default:
+		// Rejected by `New_With_Error`, see `validate_options`...
+		return T_Option[KT, VT]{
+			t:     OPTION_TYPE__WITH_HASH_MIXER,
+			other: mixer,
+		}
+	}
+
+	return T_Option[KT, VT]{
+		t:     OPTION_TYPE__WITH_HASH_MIXER,
+		other: mixer,
+		f: func(m *DAM[KT, VT]) {
+			m.hash_func = f
+		},
+	}
+}
+
+// Use the seeded built-in mixer to pick buckets.
+func With_Seeded_Hash_Mixer[KT I_Positive_Integer, VT any](seed uint64) T_Option[KT, VT] {
+	return T_Option[KT, VT]{
+		t:     OPTION_TYPE__WITH_HASH_MIXER,
+		other: HASH_MIXER__SEEDED,
+		f: func(m *DAM[KT, VT]) {
+			m.hash_func = func(k KT) uint64 { return Mix_Seeded(uint64(k), seed) }
+		},
+	}
+}
+
+// Let `Get_Many` look keys up `batch_size` at a time.
+//
+// All bucket indices of a batch are computed first and the buckets are then scanned side by side,
+// so the cache misses of the batch overlap instead of being paid one after the other.
+//
+// - NOTE: `batch_size` must be between 1 and 64, without this option `Get_Many` is a loop of `Get`.
+func With_Experimental_Batched_Gets[KT I_Positive_Integer, VT any](batch_size uint64) T_Option[KT, VT] {
+	return T_Option[KT, VT]{
+		t:     OPTION_TYPE__WITH_EXPERIMENTAL_BATCHED_GETS,
+		other: batch_size,
 		f: func(m *DAM[KT, VT]) {
-			m.users_chosen_hash_func = f
-			m.using_users_hash_func = true
+			m.get_batch_size = int(batch_size)
 		},
 	}
 }
@@ -43,3 +111,186 @@ func With_Performance_Profile[KT I_Positive_Integer, VT any](p T_Performance_Pro
 		other: p,
 	}
 }
+
+// The shape of the buckets of a `DAM`.
+type T_Bucket_Geometry struct {
+	// The average bucket length the map is sized for, it grows once this is exceeded.
+	// The performance profiles use 2 for fast, 4 for normal and 8 for save memory.
+	Entries_Per_Bucket uint64
+	// The number of entries every bucket preallocates.
+	// With 0, a bucket allocates on its first `Set`.
+	Initial_Bucket_Capacity uint64
+}
+
+// Pick the bucket geometry directly instead of through a `T_Performance_Profile`.
+//
+// This is meant for sweeping configurations in benchmarks, e.g. 1 entry per bucket to reproduce the
+// findings in `TODO.md`.
+//
+// - NOTE: Cannot be combined with `With_Performance_Profile`.
+//
+// - NOTE: Without `With_Range_Reduction`, the bucket count is rounded up to a power of two,
+// so an `entries_per_bucket` that is not a power of two gives shorter buckets than asked for.
+func With_Bucket_Geometry[KT I_Positive_Integer, VT any](
+	entries_per_bucket uint64,
+	initial_bucket_capacity uint64,
+) T_Option[KT, VT] {
+	return T_Option[KT, VT]{
+		t: OPTION_TYPE__WITH_BUCKET_GEOMETRY,
+		other: T_Bucket_Geometry{
+			Entries_Per_Bucket:      entries_per_bucket,
+			Initial_Bucket_Capacity: initial_bucket_capacity,
+		},
+	}
+}
+
+type T_Delete_Strategy uint8
+
+const (
+	// Move the last entry of the bucket into the freed slot.
+	// This is O(1) but does not preserve the insertion order within the bucket.
+	DELETE_STRATEGY__SWAP_REMOVE T_Delete_Strategy = iota
+	// Shift every following entry of the bucket down by one.
+	// This preserves the insertion order within the bucket.
+	DELETE_STRATEGY__ORDERED_SHIFT
+)
+
+func With_Delete_Strategy[KT I_Positive_Integer, VT any](s T_Delete_Strategy) T_Option[KT, VT] {
+	return T_Option[KT, VT]{
+		t:     OPTION_TYPE__WITH_DELETE_STRATEGY,
+		other: s,
+		f: func(m *DAM[KT, VT]) {
+			m.delete_strategy = s
+		},
+	}
+}
+
+// Enable or disable automatic growth, it is enabled by default.
+//
+// When enabled, `Set` doubles the number of buckets once the average bucket length exceeds the
+// target of the chosen `T_Performance_Profile` (2 for fast, 4 for normal, 8 for save memory).
+//
+// Disable it on latency-sensitive paths where a single `Set` must never rehash the whole map.
+func With_Auto_Growth[KT I_Positive_Integer, VT any](enabled bool) T_Option[KT, VT] {
+	return T_Option[KT, VT]{
+		t:     OPTION_TYPE__WITH_AUTO_GROWTH,
+		other: enabled,
+		f: func(m *DAM[KT, VT]) {
+			m.auto_growth_disabled = !enabled
+		},
+	}
+}
+
+// Spread the cost of growing over the following operations instead of rehashing every entry at once.
+//
+// After a resize starts, the old and new buckets coexist and every `Set`, `Get` and `Delete` migrates
+// `buckets_per_step` old buckets, so no single operation pays for the whole rehash.
+//
+// - NOTE: A `buckets_per_step` of 0 keeps the default stop-the-world resize.
+func With_Incremental_Resize[KT I_Positive_Integer, VT any](buckets_per_step uint64) T_Option[KT, VT] {
+	return T_Option[KT, VT]{
+		t:     OPTION_TYPE__WITH_INCREMENTAL_RESIZE,
+		other: buckets_per_step,
+		f: func(m *DAM[KT, VT]) {
+			m.migrate_buckets_per_step = buckets_per_step
+		},
+	}
+}
+
+// Allow bucket counts that are not a power of two.
+//
+// By default `expected_num_inputs` is rounded up to the next power of two so that a bucket can be
+// picked with a single mask, which can nearly double the memory of a large map.
+// With range reduction the bucket count follows `expected_num_inputs` exactly and a bucket is picked
+// with a multiply-shift instead, which costs two multiplications per operation.
+func With_Range_Reduction[KT I_Positive_Integer, VT any](enabled bool) T_Option[KT, VT] {
+	return T_Option[KT, VT]{
+		t:     OPTION_TYPE__WITH_RANGE_REDUCTION,
+		other: enabled,
+	}
+}
+
+// Checks every option on its own, then the combinations, so a bad configuration is reported by
+// `New_With_Error` instead of misbehaving at first use.
+func validate_options[KT I_Positive_Integer, VT any](options []T_Option[KT, VT]) error {
+	var seen [OPTION_TYPE__WITH_BUCKET_GEOMETRY + 1]bool
+	for _, opt := range options {
+		if opt.f == nil && opt.other == nil {
+			return &T_Invalid_Option_Error{opt.t, "options must be created with one of the `With_*` functions"}
+		}
+
+		switch opt.t {
+		case OPTION_TYPE__WITH_HASH_FUNC:
+			if f, _ := opt.other.(func(KT) uint64); f == nil {
+				return &T_Invalid_Option_Error{opt.t, "the hash func is nil"}
+			}
+		case OPTION_TYPE__WITH_PERFORMANCE_PROFILE:
+			if p := opt.other.(T_Performance_Profile); p > PERFORMANCE_PROFILE__SAVE_MEMORY {
+				return &T_Invalid_Option_Error{opt.t, "unknown performance profile " + strconv.Itoa(int(p))}
+			}
+		case OPTION_TYPE__WITH_EXPERIMENTAL_BATCHED_GETS:
+			if n := opt.other.(uint64); n == 0 || n > max_get_batch_size {
+				return &T_Invalid_Option_Error{opt.t, "the batch size must be between 1 and " + strconv.Itoa(max_get_batch_size)}
+			}
+		case OPTION_TYPE__WITH_DELETE_STRATEGY:
+			if s := opt.other.(T_Delete_Strategy); s > DELETE_STRATEGY__ORDERED_SHIFT {
+				return &T_Invalid_Option_Error{opt.t, "unknown delete strategy " + strconv.Itoa(int(s))}
+			}
+		case OPTION_TYPE__WITH_HASH_MIXER:
+			if opt.f == nil {
+				mixer := opt.other.(T_Hash_Mixer)
+				if mixer == HASH_MIXER__SEEDED {
+					return &T_Invalid_Option_Error{opt.t, "the seeded mixer needs a seed, use `With_Seeded_Hash_Mixer` instead"}
+				}
+				return &T_Invalid_Option_Error{opt.t, "unknown hash mixer " + strconv.Itoa(int(mixer))}
+			}
+		case OPTION_TYPE__WITH_BUCKET_GEOMETRY:
+			if opt.other.(T_Bucket_Geometry).Entries_Per_Bucket == 0 {
+				return &T_Invalid_Option_Error{opt.t, "the entries per bucket must be at least 1"}
+			}
+		case OPTION_TYPE__WITH_AUTO_GROWTH,
+			OPTION_TYPE__WITH_INCREMENTAL_RESIZE,
+			OPTION_TYPE__WITH_RANGE_REDUCTION:
+		default:
+			return &T_Invalid_Option_Error{opt.t, "unknown option type"}
+		}
+
+		if seen[opt.t] {
+			return &T_Conflicting_Options_Error{opt.t, opt.t, "the option is given more than once"}
+		}
+		seen[opt.t] = true
+	}
+
+	if seen[OPTION_TYPE__WITH_HASH_FUNC] && seen[OPTION_TYPE__WITH_HASH_MIXER] {
+		return &T_Conflicting_Options_Error{
+			OPTION_TYPE__WITH_HASH_MIXER, OPTION_TYPE__WITH_HASH_FUNC,
+			"only one of them can pick the buckets",
+		}
+	}
+
+	if seen[OPTION_TYPE__WITH_PERFORMANCE_PROFILE] && seen[OPTION_TYPE__WITH_BUCKET_GEOMETRY] {
+		return &T_Conflicting_Options_Error{
+			OPTION_TYPE__WITH_BUCKET_GEOMETRY, OPTION_TYPE__WITH_PERFORMANCE_PROFILE,
+			"both pick the bucket geometry",
+		}
+	}
+
+	// Incremental resize only has something to spread when the map grows...
+	auto_growth, incremental := true, false
+	for _, opt := range options {
+		switch opt.t {
+		case OPTION_TYPE__WITH_AUTO_GROWTH:
+			auto_growth = opt.other.(bool)
+		case OPTION_TYPE__WITH_INCREMENTAL_RESIZE:
+			incremental = opt.other.(uint64) != 0
+		}
+	}
+	if incremental && !auto_growth {
+		return &T_Conflicting_Options_Error{
+			OPTION_TYPE__WITH_INCREMENTAL_RESIZE, OPTION_TYPE__WITH_AUTO_GROWTH,
+			"incremental resize needs auto growth to be enabled",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// The entries of one bucket, never modified once published.
+type t_rcu_bucket[KT I_Positive_Integer, VT any] struct {
+	entries []t_bucket_entry[KT, VT]
+}
+
+type t_rcu_table[KT I_Positive_Integer, VT any] struct {
+	buckets        []atomic.Pointer[t_rcu_bucket[KT, VT]]
+	num_buckets_m1 uint64
+}
+
+// A `DAM` for read-mostly workloads, where `Get` never locks.
+//
+// Readers load the table and then the bucket through atomic pointers. Writers are serialized by a mutex,
+// copy the bucket they change and publish the copy atomically, so a reader always sees a complete bucket
+// (read-copy-update). Growing builds a whole new table and publishes it the same way.
+//
+// - NOTE: Every write copies a bucket, so writes cost more than on a `DAM` or `Concurrent_DAM`.
+type RCU_DAM[KT I_Positive_Integer, VT any] struct {
+	// Holds the hashing and growth configuration, its own buckets are not used.
+	// Only accessed by writers, except for the fields that never change after `New`.
+	config *DAM[KT, VT]
+
+	table atomic.Pointer[t_rcu_table[KT, VT]]
+
+	writer_mu   sync.Mutex
+	num_entries atomic.Int64
+}
+
+// Accepts the same options as `New`, except `With_Incremental_Resize`.
+//
+// - NOTE: Panics if the options are invalid.
+func New_RCU_DAM[KT I_Positive_Integer, VT any](
+	expected_num_inputs KT,
+	options ...T_Option[KT, VT],
+) *RCU_DAM[KT, VT] {
+	for _, opt := range options {
+		if opt.t == OPTION_TYPE__WITH_INCREMENTAL_RESIZE {
+			if step, _ := opt.other.(uint64); step != 0 {
+				panic(&T_Invalid_Option_Error{opt.t, "`RCU_DAM` always publishes a whole new table when it grows"})
+			}
+		}
+	}
+
+	config := New(expected_num_inputs, options...)
+
+	inst := RCU_DAM[KT, VT]{
+		config: config,
+	}
+	inst.table.Store(&t_rcu_table[KT, VT]{
+		buckets:        make([]atomic.Pointer[t_rcu_bucket[KT, VT]], config.num_buckets_m1+1),
+		num_buckets_m1: config.num_buckets_m1,
+	})
+
+	// Only the geometry is needed from here on...
+	config.buckets = nil
+	config.small_map_bucket[0].entries = nil
+
+	return &inst
+}
+
+func (r *RCU_DAM[KT, VT]) Enquire_Number_Of_Buckets() uint64 {
+	return r.table.Load().num_buckets_m1 + 1
+}
+
+// Returns the number of entries currently stored in the map.
+//
+// - NOTE: With concurrent writers, the result may be outdated as soon as it is returned.
+func (r *RCU_DAM[KT, VT]) Len() int {
+	return int(r.num_entries.Load())
+}
+
+// Get a value from the map without locking.
+//
+// The result reflects every write that completed before `Get` was called.
+func (r *RCU_DAM[KT, VT]) Get(key KT) (VT, bool) {
+	t := r.table.Load()
+	buck := t.buckets[r.config.reduce(r.config.hash(key), t.num_buckets_m1)].Load()
+
+	if buck != nil {
+		for i := 0; i < len(buck.entries); i++ {
+			if buck.entries[i].key == key {
+				return buck.entries[i].value, true
+			}
+		}
+	}
+
+	var zero VT
+	return zero, false
+}
+
+// Returns the table and the bucket slot of `key`, must be called with `writer_mu` held.
+func (r *RCU_DAM[KT, VT]) slot_of(key KT) *atomic.Pointer[t_rcu_bucket[KT, VT]] {
+	t := r.table.Load()
+	return &t.buckets[r.config.reduce(r.config.hash(key), t.num_buckets_m1)]
+}
+
+// Set a key-value pair in the map, publishing a copy of the key's bucket.
+func (r *RCU_DAM[KT, VT]) Set(key KT, value VT) {
+	r.writer_mu.Lock()
+	defer r.writer_mu.Unlock()
+
+	slot := r.slot_of(key)
+	var old []t_bucket_entry[KT, VT]
+	if buck := slot.Load(); buck != nil {
+		old = buck.entries
+	}
+
+	for i := range old {
+		if old[i].key == key {
+			entries := make([]t_bucket_entry[KT, VT], len(old))
+			copy(entries, old)
+			entries[i].value = value
+			slot.Store(&t_rcu_bucket[KT, VT]{entries: entries})
+			return
+		}
+	}
+
+	entries := make([]t_bucket_entry[KT, VT], len(old)+1)
+	copy(entries, old)
+	entries[len(old)] = t_bucket_entry[KT, VT]{key: key, value: value}
+	slot.Store(&t_rcu_bucket[KT, VT]{entries: entries})
+
+	num_entries := r.num_entries.Add(1)
+	if int(num_entries) > r.config.grow_at_num_entries {
+		r.grow(int(num_entries))
+	}
+}
+
+// Delete an entry from the map and return a boolean indicating whether the entry was found.
+func (r *RCU_DAM[KT, VT]) Delete(key KT) bool {
+	r.writer_mu.Lock()
+	defer r.writer_mu.Unlock()
+
+	slot := r.slot_of(key)
+	buck := slot.Load()
+	if buck == nil {
+		return false
+	}
+
+	for i := range buck.entries {
+		if buck.entries[i].key == key {
+			entries := make([]t_bucket_entry[KT, VT], len(buck.entries))
+			copy(entries, buck.entries)
+
+			// The copy is private until it is published, so the usual strategies apply...
+			b := bucket[KT, VT]{entries: entries}
+			b.remove(key, r.config.delete_strategy)
+			if len(b.entries) == 0 {
+				slot.Store(nil)
+			} else {
+				slot.Store(&t_rcu_bucket[KT, VT]{entries: b.entries})
+			}
+
+			r.num_entries.Add(-1)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rehash every entry into a new table and publish it, must be called with `writer_mu` held.
+//
+// The old table is left untouched, so readers still holding it see the map as it was before growing.
+func (r *RCU_DAM[KT, VT]) grow(num_entries int) {
+	old := r.table.Load()
+
+	r.config.num_entries = num_entries
+	num_buckets := r.config.next_num_buckets()
+	r.config.num_buckets_m1 = num_buckets - 1
+	r.config.update_growth_threshold()
+
+	index_of := func(key KT) uint64 {
+		return r.config.reduce(r.config.hash(key), num_buckets-1)
+	}
+
+	counts := make([]int, num_buckets)
+	for i := range old.buckets {
+		if buck := old.buckets[i].Load(); buck != nil {
+			for _, e := range buck.entries {
+				counts[index_of(e.key)]++
+			}
+		}
+	}
+
+	// Carve every bucket out of a single allocation, with its exact size...
+	all_entries := make([]t_bucket_entry[KT, VT], 0, num_entries)
+	all_buckets := make([]t_rcu_bucket[KT, VT], num_buckets)
+	offset := 0
+	for i, c := range counts {
+		all_buckets[i].entries = all_entries[offset : offset : offset+c]
+		offset += c
+	}
+	for i := range old.buckets {
+		if buck := old.buckets[i].Load(); buck != nil {
+			for _, e := range buck.entries {
+				b := &all_buckets[index_of(e.key)]
+				b.entries = append(b.entries, e)
+			}
+		}
+	}
+
+	t := &t_rcu_table[KT, VT]{
+		buckets:        make([]atomic.Pointer[t_rcu_bucket[KT, VT]], num_buckets),
+		num_buckets_m1: num_buckets - 1,
+	}
+	for i := range all_buckets {
+		if len(all_buckets[i].entries) != 0 {
+			t.buckets[i].Store(&all_buckets[i])
+		}
+	}
+	r.table.Store(t)
+}
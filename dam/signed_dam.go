@@ -0,0 +1,141 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam
+
+import "iter"
+
+// Any signed integer type, including defined types such as `type Offset int64`.
+type I_Signed_Integer interface {
+	~int8 | ~int16 | ~int32 | ~int64
+}
+
+const sign_bit = uint64(1) << 63
+
+// Maps a signed key onto a uint64 while preserving order: the minimum of KT maps below -1,
+// which maps below 0, which maps below the maximum of KT.
+//
+// Only the sign bit is flipped, so the low bits a bucket mask looks at are those of the original key
+// and small negative keys (which fill the last buckets) spread just as well as small positive ones.
+//
+//go:inline
+func signed_to_ordered[KT I_Signed_Integer](k KT) uint64 {
+	return uint64(int64(k)) ^ sign_bit
+}
+
+//go:inline
+func ordered_to_signed[KT I_Signed_Integer](u uint64) KT {
+	return KT(int64(u ^ sign_bit))
+}
+
+// A `DAM` for signed integer keys.
+//
+// Keys are stored in a `DAM[uint64, VT]` through an order-preserving mapping, see `signed_to_ordered`.
+//
+// - NOTE: Every key takes 8 bytes whatever the width of KT, since the options are keyed by uint64.
+// With small values this dominates the memory use, e.g. an int8 key with a uint8 value takes 16 bytes
+// per entry where a `DAM[uint8, uint8]` entry takes 2. If that matters, flip the sign bit yourself
+// and use a `DAM` of the unsigned type of the same width.
+type Signed_DAM[KT I_Signed_Integer, VT any] struct {
+	map_ *DAM[uint64, VT]
+}
+
+// Accepts the same options as `New`, keyed by uint64.
+//
+// - NOTE: A hash func given with `With_Hash_Func` receives the mapped key, i.e. the key with its sign bit flipped.
+func New_Signed_DAM[KT I_Signed_Integer, VT any](
+	expected_num_inputs uint64,
+	options ...T_Option[uint64, VT],
+) *Signed_DAM[KT, VT] {
+	return &Signed_DAM[KT, VT]{
+		map_: New(expected_num_inputs, options...),
+	}
+}
+
+// NOTE: Returns a uint64 since the number of buckets may not fit in KT.
+func (m *Signed_DAM[KT, VT]) Enquire_Number_Of_Buckets() uint64 {
+	return m.map_.Enquire_Number_Of_Buckets()
+}
+
+// Returns the number of entries currently stored in the map.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *Signed_DAM[KT, VT]) Len() int {
+	return m.map_.Len()
+}
+
+// Remove every entry from the map, keeping the allocated buckets.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *Signed_DAM[KT, VT]) Clear() {
+	m.map_.Clear()
+}
+
+// See `DAM.Stats`.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *Signed_DAM[KT, VT]) Stats() T_Stats {
+	return m.map_.Stats()
+}
+
+// Set a key-value pair in the map.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+//go:inline
+func (m *Signed_DAM[KT, VT]) Set(key KT, value VT) {
+	m.map_.Set(signed_to_ordered(key), value)
+}
+
+// Returns the value and a boolean indicating whether the value was found.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+//go:inline
+func (m *Signed_DAM[KT, VT]) Get(key KT) (VT, bool) {
+	return m.map_.Get(signed_to_ordered(key))
+}
+
+// Delete an entry from the map and return a boolean indicating whether the entry was found.
+//
+// - WARNING: This function is NOT thread-safe.
+//
+//go:inline
+func (m *Signed_DAM[KT, VT]) Delete(key KT) bool {
+	return m.map_.Delete(signed_to_ordered(key))
+}
+
+// Returns an iterator over every key-value pair in the map, see `DAM.All` for the mutation rules.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *Signed_DAM[KT, VT]) All() iter.Seq2[KT, VT] {
+	return func(yield func(KT, VT) bool) {
+		for k, v := range m.map_.All() {
+			if !yield(ordered_to_signed[KT](k), v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over every key in the map, see `DAM.All` for the mutation rules.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *Signed_DAM[KT, VT]) Keys() iter.Seq[KT] {
+	return func(yield func(KT) bool) {
+		for k := range m.map_.Keys() {
+			if !yield(ordered_to_signed[KT](k)) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over every value in the map, see `DAM.All` for the mutation rules.
+//
+// - WARNING: This function is NOT thread-safe.
+func (m *Signed_DAM[KT, VT]) Values() iter.Seq[VT] {
+	return m.map_.Values()
+}
@@ -0,0 +1,77 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Auto_Tune_Avoids_Identity_For_Strided_Keys(t *testing.T) {
+	// Every key is a multiple of 1024, so the identity mixer piles them into a handful of buckets...
+	sample := make([]uint64, 1<<14)
+	for i := range sample {
+		sample[i] = uint64(i+1) * 1024
+	}
+
+	res, err := dam.Auto_Tune[uint64, uint64](sample, dam.T_Tune_Config{Rounds: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.Best.Mixer == dam.HASH_MIXER__IDENTITY {
+		t.Fatalf("Expected a mixer to be picked for strided keys, got %v.", res.Best.Mixer)
+	}
+	if len(res.Trials) == 0 {
+		t.Fatalf("Expected the trials to be reported.")
+	}
+
+	// The result must be usable as is...
+	dam_map := dam.New(uint64(len(sample)), dam.Tuned_Options[uint64, uint64](res.Best)...)
+	for i, k := range sample {
+		dam_map.Set(k, uint64(i))
+	}
+	if s := dam_map.Stats(); s.Max_Entries_Per_Bucket > 64 {
+		t.Fatalf("Tuned map has a bucket of %d entries.", s.Max_Entries_Per_Bucket)
+	}
+}
+
+func Test_Auto_Tune_Respects_Memory_Budget(t *testing.T) {
+	sample := make([]uint64, 1<<12)
+	for i := range sample {
+		sample[i] = uint64(i + 1)
+	}
+
+	unbounded, err := dam.Auto_Tune[uint64, uint64](sample, dam.T_Tune_Config{Rounds: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	smallest := unbounded.Trials[0].Memory_Bytes
+	for _, trial := range unbounded.Trials {
+		smallest = min(smallest, trial.Memory_Bytes)
+	}
+
+	res, err := dam.Auto_Tune[uint64, uint64](sample, dam.T_Tune_Config{Rounds: 1, Memory_Budget_Bytes: smallest})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !res.Best.Within_Budget || res.Best.Memory_Bytes > smallest {
+		t.Fatalf("Best trial uses %d bytes for a budget of %d.", res.Best.Memory_Bytes, smallest)
+	}
+
+	_, err = dam.Auto_Tune[uint64, uint64](sample, dam.T_Tune_Config{Rounds: 1, Memory_Budget_Bytes: 1})
+	if !errors.Is(err, dam.Err_Memory_Budget_Too_Small) {
+		t.Fatalf("Expected `Err_Memory_Budget_Too_Small`, got %v.", err)
+	}
+}
+
+func Test_Auto_Tune_Rejects_Empty_Sample(t *testing.T) {
+	if _, err := dam.Auto_Tune[uint64, uint64](nil, dam.T_Tune_Config{}); !errors.Is(err, dam.Err_Empty_Sample) {
+		t.Fatalf("Expected `Err_Empty_Sample`, got %v.", err)
+	}
+}
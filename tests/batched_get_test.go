@@ -0,0 +1,117 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Get_Many_Against_Get(t *testing.T) {
+	const n = 1 << 14
+
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Unbatched": nil,
+		"Batch_1":   {dam.With_Experimental_Batched_Gets[uint64, uint64](1)},
+		"Batch_7":   {dam.With_Experimental_Batched_Gets[uint64, uint64](7)},
+		"Batch_64_Mixer": {
+			dam.With_Experimental_Batched_Gets[uint64, uint64](64),
+			dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__MURMUR3),
+		},
+		"Batch_16_Incremental": {
+			dam.With_Experimental_Batched_Gets[uint64, uint64](16),
+			dam.With_Incremental_Resize[uint64, uint64](1),
+		},
+	}
+
+	for name, options := range option_sets {
+		rng := rand.New(rand.NewSource(1))
+		dam_map := dam.New(uint64(64), options...)
+
+		// Half of the looked up keys are missing...
+		keys := make([]uint64, 2*n)
+		for i := range keys {
+			keys[i] = uint64(rng.Intn(4 * n))
+			if i%2 == 0 {
+				dam_map.Set(keys[i], keys[i]*3)
+			}
+		}
+
+		out := make([]uint64, len(keys))
+		found := make([]bool, len(keys))
+		for i := range out {
+			out[i] = 42
+		}
+		dam_map.Get_Many(keys, out, found)
+
+		for i, key := range keys {
+			v, ok := dam_map.Get(key)
+			if out[i] != v || found[i] != ok {
+				t.Fatalf("%s: Get_Many gave (%d, %v) for key %d, Get gave (%d, %v).", name, out[i], found[i], key, v, ok)
+			}
+		}
+	}
+}
+
+func Test_Get_Many_Rejects_Short_Outputs(t *testing.T) {
+	dam_map := dam.New(uint64(64), dam.With_Experimental_Batched_Gets[uint64, uint64](8))
+
+	expect_panic(t, "a short `out`", func() {
+		dam_map.Get_Many(make([]uint64, 4), make([]uint64, 3), make([]bool, 4))
+	})
+
+	_, err := dam.New_With_Error(uint64(64), dam.With_Experimental_Batched_Gets[uint64, uint64](65))
+	if err == nil {
+		t.Fatalf("Expected a batch size of 65 to be rejected.")
+	}
+}
+
+func bench_random_DAM_get_many(b *testing.B, batch_size uint64) {
+	const n = 1 << 22
+	const chunk = 1024
+
+	var options []dam.T_Option[uint64, uint64]
+	if batch_size != 0 {
+		options = append(options, dam.With_Experimental_Batched_Gets[uint64, uint64](batch_size))
+	}
+	dam_map := dam.New(uint64(n), options...)
+
+	rng := rand.New(rand.NewSource(1))
+	keys := make([]uint64, n)
+	for i := range keys {
+		keys[i] = rng.Uint64()
+		dam_map.Set(keys[i], uint64(i))
+	}
+	rng.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+	out := make([]uint64, chunk)
+	found := make([]bool, chunk)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i += chunk {
+		start := i % (n - chunk)
+		dam_map.Get_Many(keys[start:start+chunk], out, found)
+		if !found[0] {
+			panic("Key not found.")
+		}
+	}
+}
+
+func Benchmark_Random_DAM_Get_Many(b *testing.B) {
+	// Without batched gets, `Get_Many` is a plain loop of `Get`...
+	b.Run("Get_Loop", func(b *testing.B) {
+		bench_random_DAM_get_many(b, 0)
+	})
+
+	for _, batch_size := range []uint64{4, 8, 16, 32, 64} {
+		b.Run(fmt.Sprintf("Batch_%d", batch_size), func(b *testing.B) {
+			bench_random_DAM_get_many(b, batch_size)
+		})
+	}
+}
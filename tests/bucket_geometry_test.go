@@ -0,0 +1,141 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Bucket_Geometry_Sets_Bucket_Count(t *testing.T) {
+	const expected = 1 << 16
+
+	for _, entries_per_bucket := range []uint64{1, 2, 4, 16} {
+		dam_map := dam.New(uint64(expected), dam.With_Bucket_Geometry[uint64, uint64](entries_per_bucket, 0))
+
+		if n := dam_map.Enquire_Number_Of_Buckets(); n != expected/entries_per_bucket {
+			t.Fatalf("%d entries per bucket: got %d buckets, expected %d.", entries_per_bucket, n, expected/entries_per_bucket)
+		}
+		g := dam_map.Enquire_Bucket_Geometry()
+		if g.Entries_Per_Bucket != entries_per_bucket || g.Initial_Bucket_Capacity != 0 {
+			t.Fatalf("%d entries per bucket: Enquire_Bucket_Geometry() = %+v.", entries_per_bucket, g)
+		}
+	}
+}
+
+func Test_Bucket_Geometry_Rounds_Odd_Entries_Per_Bucket(t *testing.T) {
+	const expected = 1 << 16
+
+	// The bucket mask needs a power of two bucket count to reach every bucket...
+	for _, entries_per_bucket := range []uint64{3, 5, 7} {
+		dam_map := dam.New(uint64(expected), dam.With_Bucket_Geometry[uint64, uint64](entries_per_bucket, 0))
+		if n := dam_map.Enquire_Number_Of_Buckets(); n&(n-1) != 0 || n < expected/entries_per_bucket {
+			t.Fatalf("%d entries per bucket: got %d buckets, expected a power of two of at least %d.", entries_per_bucket, n, expected/entries_per_bucket)
+		}
+	}
+
+	// Range reduction reaches any bucket count, so none is wasted...
+	dam_map := dam.New(uint64(expected),
+		dam.With_Bucket_Geometry[uint64, uint64](3, 0),
+		dam.With_Range_Reduction[uint64, uint64](true),
+	)
+	if n := dam_map.Enquire_Number_Of_Buckets(); n != expected/3 {
+		t.Fatalf("Range reduction: got %d buckets, expected %d.", n, expected/3)
+	}
+}
+
+func Test_Bucket_Geometry_Defaults_To_Profile(t *testing.T) {
+	profiles := map[dam.T_Performance_Profile]uint64{
+		dam.PERFORMANCE_PROFILE__FAST:        2,
+		dam.PERFORMANCE_PROFILE__NORMAL:      4,
+		dam.PERFORMANCE_PROFILE__SAVE_MEMORY: 8,
+	}
+
+	for profile, entries_per_bucket := range profiles {
+		dam_map := dam.New(uint64(1024), dam.With_Performance_Profile[uint64, uint64](profile))
+		want := dam.T_Bucket_Geometry{Entries_Per_Bucket: entries_per_bucket, Initial_Bucket_Capacity: entries_per_bucket}
+		if g := dam_map.Enquire_Bucket_Geometry(); g != want {
+			t.Fatalf("Profile %d: Enquire_Bucket_Geometry() = %+v, expected %+v.", profile, g, want)
+		}
+	}
+}
+
+func Test_Bucket_Geometry_Growth_Keeps_Target(t *testing.T) {
+	const n = 1 << 16
+
+	for _, entries_per_bucket := range []uint64{1, 3, 16} {
+		rng := rand.New(rand.NewSource(1))
+		builtin_map := make(map[uint64]uint64)
+		dam_map := dam.New(uint64(64), dam.With_Bucket_Geometry[uint64, uint64](entries_per_bucket, 1))
+
+		for i := 0; i < n; i++ {
+			key := rng.Uint64()
+			builtin_map[key] = uint64(i)
+			dam_map.Set(key, uint64(i))
+		}
+
+		s := dam_map.Stats()
+		if s.Mean_Entries_Per_Bucket > float64(entries_per_bucket) {
+			t.Fatalf("%d entries per bucket: mean bucket length %f after growth.", entries_per_bucket, s.Mean_Entries_Per_Bucket)
+		}
+		// The mean alone looks fine when the keys are crammed into a few reachable buckets...
+		if s.Max_Entries_Per_Bucket > 3*entries_per_bucket+8 {
+			t.Fatalf("%d entries per bucket: longest bucket holds %d entries after growth.", entries_per_bucket, s.Max_Entries_Per_Bucket)
+		}
+		if empty := s.Bucket_Length_Histogram[0]; empty > s.Num_Buckets/2 {
+			t.Fatalf("%d entries per bucket: %d of %d buckets are empty after growth.", entries_per_bucket, empty, s.Num_Buckets)
+		}
+		check_against_builtin_map(t, dam_map, builtin_map)
+	}
+}
+
+func Test_Bucket_Geometry_Validation(t *testing.T) {
+	if _, err := dam.New_With_Error(uint64(1024), dam.With_Bucket_Geometry[uint64, uint64](0, 4)); err == nil {
+		t.Fatalf("Expected 0 entries per bucket to be rejected.")
+	}
+
+	_, err := dam.New_With_Error(uint64(1024),
+		dam.With_Bucket_Geometry[uint64, uint64](1, 1),
+		dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__FAST),
+	)
+	if _, ok := err.(*dam.T_Conflicting_Options_Error); !ok {
+		t.Fatalf("Expected a geometry and a profile to conflict, got %v.", err)
+	}
+}
+
+// Sweeps the geometry like the "1 entry per bucket" findings in `TODO.md`.
+func Benchmark_Random_DAM_Get_Bucket_Geometry(b *testing.B) {
+	const n = 1 << 20
+
+	rng := rand.New(rand.NewSource(1))
+	keys := make([]uint64, n)
+	for i := range keys {
+		keys[i] = rng.Uint64()
+	}
+
+	for _, entries_per_bucket := range []uint64{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("Entries_Per_Bucket_%d", entries_per_bucket), func(b *testing.B) {
+			dam_map := dam.New(uint64(n), dam.With_Bucket_Geometry[uint64, uint64](entries_per_bucket, entries_per_bucket))
+			for i, key := range keys {
+				dam_map.Set(key, uint64(i))
+			}
+
+			var t uint64
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				x, ok := dam_map.Get(keys[uint64(i)*7919%n])
+				if ok {
+					t += x
+				} else {
+					panic("Key not found.")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Build_From_Parallel_Against_Builtin_Map(t *testing.T) {
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Default": nil,
+		"Fast":    {dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__FAST)},
+		"Range":   {dam.With_Range_Reduction[uint64, uint64](true)},
+		"Mixer":   {dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__SPLITMIX64)},
+	}
+
+	for name, options := range option_sets {
+		for _, num_workers := range []int{0, 1, 2, 3, 8} {
+			const n = 1 << 17
+
+			rng := rand.New(rand.NewSource(int64(num_workers)))
+			builtin_map := make(map[uint64]uint64)
+
+			// Repeated keys end up in different chunks, the last value must still win...
+			keys := make([]uint64, n)
+			values := make([]uint64, n)
+			for i := range keys {
+				keys[i] = uint64(rng.Intn(n / 2))
+				values[i] = uint64(i)
+				builtin_map[keys[i]] = values[i]
+			}
+
+			dam_map := dam.Build_From_Parallel(keys, values, false, num_workers, options...)
+			t.Run(fmt.Sprintf("%s/%d_Workers", name, num_workers), func(t *testing.T) {
+				check_against_builtin_map(t, dam_map, builtin_map)
+			})
+		}
+	}
+}
+
+func Test_Build_From_Parallel_Unique_Keys(t *testing.T) {
+	const n = 1 << 17
+
+	rng := rand.New(rand.NewSource(1))
+	keys := make([]uint64, n)
+	values := make([]uint64, n)
+	for i := range keys {
+		keys[i] = uint64(i + 1)
+		values[i] = rng.Uint64()
+	}
+	rng.Shuffle(n, func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+	dam_map := dam.Build_From_Parallel(keys, values, true, 4)
+	if dam_map.Len() != n {
+		t.Fatalf("Len() = %d, expected %d.", dam_map.Len(), n)
+	}
+	for i, key := range keys {
+		if v, ok := dam_map.Get(key); !ok || v != values[i] {
+			t.Fatalf("Get(%d) = (%d, %v), expected (%d, true).", key, v, ok, values[i])
+		}
+	}
+
+	// The map keeps growing normally afterwards...
+	for i := uint64(n + 1); i <= 2*n; i++ {
+		dam_map.Set(i, i)
+	}
+	if dam_map.Len() != 2*n {
+		t.Fatalf("Len() = %d after growing, expected %d.", dam_map.Len(), 2*n)
+	}
+}
+
+func Test_Build_From_Parallel_Small_Inputs(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 100} {
+		keys := make([]uint64, n)
+		values := make([]uint64, n)
+		for i := range keys {
+			keys[i] = uint64(i)
+			values[i] = uint64(i) * 2
+		}
+
+		dam_map := dam.Build_From_Parallel(keys, values, true, 8)
+		if dam_map.Len() != n {
+			t.Fatalf("%d keys: Len() = %d.", n, dam_map.Len())
+		}
+		for i, key := range keys {
+			if v, ok := dam_map.Get(key); !ok || v != values[i] {
+				t.Fatalf("%d keys: Get(%d) = (%d, %v).", n, key, v, ok)
+			}
+		}
+	}
+}
+
+// Run with different `-cpu` values, or read the sub-benchmarks, to see how the build scales.
+func Benchmark_Random_Build_From_Parallel(b *testing.B) {
+	const n = 1 << 22
+
+	rng := rand.New(rand.NewSource(1))
+	keys := make([]uint64, n)
+	values := make([]uint64, n)
+	for i := range keys {
+		keys[i] = rng.Uint64()
+		values[i] = uint64(i)
+	}
+
+	for _, procs := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("GOMAXPROCS_%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+
+			var elapsed time.Duration
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				dam_map := dam.Build_From_Parallel(keys, values, true, 0)
+				elapsed += time.Since(start)
+
+				if dam_map.Len() != n {
+					panic("Wrong length.")
+				}
+			}
+			b.ReportMetric(float64(elapsed.Nanoseconds())/float64(b.N*n), "ns/key")
+		})
+	}
+}
@@ -0,0 +1,216 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Build_From_Against_Builtin_Map(t *testing.T) {
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Default": nil,
+		"Fast":    {dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__FAST)},
+		"Range":   {dam.With_Range_Reduction[uint64, uint64](true)},
+		"Mixer":   {dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__MURMUR3)},
+	}
+
+	for name, options := range option_sets {
+		for _, n := range []int{0, 5, 1001, 1 << 14} {
+			rng := rand.New(rand.NewSource(int64(n)))
+			builtin_map := make(map[uint64]uint64)
+
+			// Repeated keys, the last value wins like with `Set`...
+			keys := make([]uint64, n)
+			values := make([]uint64, n)
+			for i := range keys {
+				keys[i] = uint64(rng.Intn(n/2 + 1))
+				values[i] = uint64(i)
+				builtin_map[keys[i]] = values[i]
+			}
+
+			dam_map := dam.Build_From(keys, values, false, options...)
+			t.Run(fmt.Sprintf("%s/%d", name, n), func(t *testing.T) {
+				check_against_builtin_map(t, dam_map, builtin_map)
+			})
+
+			// The map keeps working after a bulk load...
+			dam_map.Set(1<<40, 1)
+			if v, ok := dam_map.Get(1 << 40); !ok || v != 1 {
+				t.Fatalf("%s, %d keys: Set after Build_From was lost.", name, n)
+			}
+		}
+	}
+}
+
+func Test_Build_From_Unique_Keys(t *testing.T) {
+	const n = 1 << 14
+
+	keys := make([]uint64, n)
+	values := make([]uint64, n)
+	for i := range keys {
+		keys[i] = uint64(i) * 7
+		values[i] = uint64(i)
+	}
+
+	dam_map := dam.Build_From(keys, values, true)
+	if dam_map.Len() != n {
+		t.Fatalf("Len() = %d, expected %d.", dam_map.Len(), n)
+	}
+	for i, key := range keys {
+		if v, ok := dam_map.Get(key); !ok || v != values[i] {
+			t.Fatalf("Get(%d) = (%d, %v), expected (%d, true).", key, v, ok, values[i])
+		}
+	}
+	if s := dam_map.Stats(); s.Used_Bytes != s.Capacity_Bytes {
+		t.Fatalf("Expected every bucket to be allocated exactly, used %d of %d bytes.", s.Used_Bytes, s.Capacity_Bytes)
+	}
+}
+
+func Test_Set_Many_Against_Builtin_Map(t *testing.T) {
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Default":     nil,
+		"Incremental": {dam.With_Incremental_Resize[uint64, uint64](1)},
+		"No_Growth":   {dam.With_Auto_Growth[uint64, uint64](false)},
+	}
+
+	for name, options := range option_sets {
+		rng := rand.New(rand.NewSource(1))
+		builtin_map := make(map[uint64]uint64)
+		dam_map := dam.New(uint64(64), options...)
+
+		// Batches of every size, overlapping with what is already in the map...
+		for batch := 1; batch <= 1<<13; batch *= 2 {
+			keys := make([]uint64, batch)
+			values := make([]uint64, batch)
+			for i := range keys {
+				keys[i] = uint64(rng.Intn(1 << 14))
+				values[i] = rng.Uint64()
+				builtin_map[keys[i]] = values[i]
+			}
+			dam_map.Set_Many(keys, values)
+		}
+
+		t.Run(name, func(t *testing.T) {
+			check_against_builtin_map(t, dam_map, builtin_map)
+		})
+	}
+}
+
+func Test_Set_Many_During_Iteration_Mid_Migration(t *testing.T) {
+	builtin_map := make(map[uint64]uint64)
+	dam_map := dam.New(uint64(1024*8), dam.With_Incremental_Resize[uint64, uint64](1))
+	fill_until_resizing(t, dam_map, builtin_map)
+
+	// Enough keys to make a reservation grow the map, even though every one of them is already present...
+	keys := make([]uint64, 0, len(builtin_map))
+	values := make([]uint64, 0, len(builtin_map))
+	for k, v := range builtin_map {
+		keys = append(keys, k)
+		values = append(values, v+1)
+		builtin_map[k] = v + 1
+	}
+
+	seen := make(map[uint64]struct{})
+	for k := range dam_map.Keys() {
+		if _, ok := seen[k]; ok {
+			t.Fatalf("Keys yielded %d twice.", k)
+		}
+		if len(seen) == 0 {
+			dam_map.Set_Many(keys, values)
+		}
+		seen[k] = struct{}{}
+	}
+	if len(seen) != len(builtin_map) {
+		t.Fatalf("Keys yielded %d keys, expected %d.", len(seen), len(builtin_map))
+	}
+	if !dam_map.Enquire_Is_Resizing() {
+		t.Fatalf("Set_Many migrated during iteration.")
+	}
+	check_against_builtin_map(t, dam_map, builtin_map)
+}
+
+func Test_Set_Many_Rejects_Mismatched_Lengths(t *testing.T) {
+	dam_map := dam.New[uint64, uint64](64)
+	expect_panic(t, "mismatched lengths", func() {
+		dam_map.Set_Many(make([]uint64, 2), make([]uint64, 1))
+	})
+}
+
+func bench_bulk_load(b *testing.B, keys []uint64, values []uint64) {
+	n := len(keys)
+
+	loaders := []struct {
+		name string
+		load func() *dam.DAM[uint64, uint64]
+	}{
+		{"Set_Loop", func() *dam.DAM[uint64, uint64] {
+			dam_map := dam.New[uint64, uint64](uint64(n))
+			for i, key := range keys {
+				dam_map.Set(key, values[i])
+			}
+			return dam_map
+		}},
+		{"Set_Many", func() *dam.DAM[uint64, uint64] {
+			dam_map := dam.New[uint64, uint64](uint64(n))
+			dam_map.Set_Many(keys, values)
+			return dam_map
+		}},
+		{"Build_From", func() *dam.DAM[uint64, uint64] {
+			return dam.Build_From(keys, values, false)
+		}},
+		{"Build_From_Unique", func() *dam.DAM[uint64, uint64] {
+			return dam.Build_From(keys, values, true)
+		}},
+	}
+
+	for _, loader := range loaders {
+		b.Run(loader.name, func(b *testing.B) {
+			var elapsed time.Duration
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				dam_map := loader.load()
+				elapsed += time.Since(start)
+
+				if dam_map.Len() != n {
+					panic("Wrong length.")
+				}
+			}
+			b.ReportMetric(float64(elapsed.Nanoseconds())/float64(b.N*n), "ns/key")
+		})
+	}
+}
+
+func Benchmark_Linear_Bulk_Load(b *testing.B) {
+	const n = 1 << 22
+
+	keys := make([]uint64, n)
+	values := make([]uint64, n)
+	for i := range keys {
+		keys[i] = uint64(i + 1)
+		values[i] = uint64(i)
+	}
+
+	bench_bulk_load(b, keys, values)
+}
+
+func Benchmark_Random_Bulk_Load(b *testing.B) {
+	const n = 1 << 22
+
+	rng := rand.New(rand.NewSource(1))
+	keys := make([]uint64, n)
+	values := make([]uint64, n)
+	for i := range keys {
+		keys[i] = rng.Uint64()
+		values[i] = uint64(i)
+	}
+
+	bench_bulk_load(b, keys, values)
+}
@@ -0,0 +1,224 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Concurrent_DAM_Against_Builtin_Maps(t *testing.T) {
+	const num_goroutines = 8
+	const num_ops = 1 << 14
+
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Default": nil,
+		"Mixer":   {dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__MURMUR3)},
+		"Ordered": {dam.With_Delete_Strategy[uint64, uint64](dam.DELETE_STRATEGY__ORDERED_SHIFT)},
+	}
+
+	for name, options := range option_sets {
+		// Start tiny so that the map grows while every goroutine is writing...
+		c := dam.New_Concurrent_DAM(uint64(16), 8, options...)
+
+		// Every goroutine owns the keys congruent to its id, so each one can check against its own builtin map...
+		builtin_maps := make([]map[uint64]uint64, num_goroutines)
+		var wg sync.WaitGroup
+		for g := 0; g < num_goroutines; g++ {
+			builtin_maps[g] = make(map[uint64]uint64)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rng := rand.New(rand.NewSource(int64(g)))
+				for i := 0; i < num_ops; i++ {
+					key := uint64(rng.Intn(num_ops))*num_goroutines + uint64(g)
+					switch rng.Intn(4) {
+					case 0:
+						_, expected := builtin_maps[g][key]
+						delete(builtin_maps[g], key)
+						if got := c.Delete(key); got != expected {
+							t.Errorf("%s: Delete(%d) = %v, expected %v.", name, key, got, expected)
+							return
+						}
+					case 1:
+						expected, expected_ok := builtin_maps[g][key]
+						if v, ok := c.Get(key); v != expected || ok != expected_ok {
+							t.Errorf("%s: Get(%d) = (%d, %v), expected (%d, %v).", name, key, v, ok, expected, expected_ok)
+							return
+						}
+					default:
+						builtin_maps[g][key] = uint64(i)
+						c.Set(key, uint64(i))
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		total := 0
+		for _, builtin_map := range builtin_maps {
+			total += len(builtin_map)
+			for key, v := range builtin_map {
+				if got, ok := c.Get(key); !ok || got != v {
+					t.Fatalf("%s: Get(%d) = (%d, %v), expected (%d, true).", name, key, got, ok, v)
+				}
+			}
+		}
+		if c.Len() != total {
+			t.Fatalf("%s: Len() = %d, expected %d.", name, c.Len(), total)
+		}
+		if c.Enquire_Number_Of_Buckets() <= 16 {
+			t.Fatalf("%s: expected the map to grow, it has %d buckets.", name, c.Enquire_Number_Of_Buckets())
+		}
+	}
+}
+
+func Test_Concurrent_DAM_Readers_See_Whole_Values(t *testing.T) {
+	const num_keys = 1 << 10
+
+	c := dam.New_Concurrent_DAM[uint64, [2]uint64](num_keys, 0)
+	for k := uint64(0); k < num_keys; k++ {
+		c.Set(k, [2]uint64{k, k})
+	}
+
+	// Writers keep both halves of a value equal, readers must never see a torn one...
+	var stop atomic.Bool
+	var writers, readers sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			rng := rand.New(rand.NewSource(int64(g)))
+			for !stop.Load() {
+				x := rng.Uint64()
+				c.Set(uint64(rng.Intn(num_keys)), [2]uint64{x, x})
+			}
+		}()
+
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			rng := rand.New(rand.NewSource(int64(g) + 100))
+			for i := 0; i < 1<<15; i++ {
+				v, ok := c.Get(uint64(rng.Intn(num_keys)))
+				if !ok || v[0] != v[1] {
+					t.Errorf("Torn or missing value %v, %v.", v, ok)
+					return
+				}
+			}
+		}()
+	}
+
+	readers.Wait()
+	stop.Store(true)
+	writers.Wait()
+}
+
+func Test_Concurrent_DAM_Rejects_Unsupported_Options(t *testing.T) {
+	expect_panic(t, "range reduction", func() {
+		dam.New_Concurrent_DAM(uint64(64), 0, dam.With_Range_Reduction[uint64, uint64](true))
+	})
+	expect_panic(t, "incremental resize", func() {
+		dam.New_Concurrent_DAM(uint64(64), 0, dam.With_Incremental_Resize[uint64, uint64](4))
+	})
+}
+
+type t_mutex_map struct {
+	mu sync.RWMutex
+	m  map[uint64]uint64
+}
+
+// The operations every concurrent map is benchmarked with.
+type t_concurrent_map struct {
+	name string
+	get  func(uint64) (uint64, bool)
+	set  func(uint64, uint64)
+}
+
+func new_concurrent_maps(n int) []t_concurrent_map {
+	c := dam.New_Concurrent_DAM[uint64, uint64](uint64(n), 0)
+
+	r := dam.New_RCU_DAM[uint64, uint64](uint64(n))
+
+	var s sync.Map
+
+	mm := t_mutex_map{m: make(map[uint64]uint64, n)}
+
+	return []t_concurrent_map{
+		{"Concurrent_DAM", c.Get, c.Set},
+		{"RCU_DAM", r.Get, r.Set},
+		{
+			"Sync_Map",
+			func(k uint64) (uint64, bool) {
+				v, ok := s.Load(k)
+				if !ok {
+					return 0, false
+				}
+				return v.(uint64), true
+			},
+			func(k uint64, v uint64) { s.Store(k, v) },
+		},
+		{
+			"RWMutex_Builtin_Map",
+			func(k uint64) (uint64, bool) {
+				mm.mu.RLock()
+				v, ok := mm.m[k]
+				mm.mu.RUnlock()
+				return v, ok
+			},
+			func(k uint64, v uint64) {
+				mm.mu.Lock()
+				mm.m[k] = v
+				mm.mu.Unlock()
+			},
+		},
+	}
+}
+
+// `set_every` of 0 means gets only.
+func bench_concurrent_maps(b *testing.B, set_every int) {
+	const n = 1 << 16
+
+	for _, m := range new_concurrent_maps(n) {
+		for k := uint64(0); k < n; k++ {
+			m.set(k, k)
+		}
+
+		b.Run(m.name, func(b *testing.B) {
+			var seed atomic.Int64
+			b.RunParallel(func(pb *testing.PB) {
+				rng := rand.New(rand.NewSource(seed.Add(1)))
+				i := 0
+				for pb.Next() {
+					k := uint64(rng.Intn(n))
+					if set_every != 0 && i%set_every == 0 {
+						m.set(k, k)
+					} else if _, ok := m.get(k); !ok {
+						panic("Key not found.")
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+func Benchmark_Concurrent_Get(b *testing.B) {
+	bench_concurrent_maps(b, 0)
+}
+
+func Benchmark_Concurrent_Get_90_Set_10(b *testing.B) {
+	bench_concurrent_maps(b, 10)
+}
+
+// The read-heavy routing table workload `RCU_DAM` is meant for.
+func Benchmark_Concurrent_Get_999_Set_1(b *testing.B) {
+	bench_concurrent_maps(b, 1000)
+}
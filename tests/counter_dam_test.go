@@ -0,0 +1,217 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Counter_DAM_Against_Builtin_Map(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	builtin_map := make(map[uint64]uint64)
+	counter := dam.New_Counter_DAM(uint64(16), dam.OVERFLOW_MODE__WRAP)
+
+	for i := 0; i < 1<<15; i++ {
+		key := uint64(rng.Intn(1 << 12))
+		if i%7 == 0 {
+			delete(builtin_map, key)
+			counter.Delete(key)
+			continue
+		}
+		delta := int64(rng.Intn(10)) - 2
+		builtin_map[key] += uint64(delta)
+		if got := counter.Add(key, delta); got != builtin_map[key] {
+			t.Fatalf("Add(%d, %d) = %d, expected %d.", key, delta, got, builtin_map[key])
+		}
+	}
+
+	check_all_against_builtin_map(t, counter.Len(), counter.All(), builtin_map)
+	sum := uint64(0)
+	for key, count := range builtin_map {
+		sum += count
+		if got := counter.Get(key); got != count {
+			t.Fatalf("Get(%d) = %d, expected %d.", key, got, count)
+		}
+	}
+	if counter.Sum() != sum {
+		t.Fatalf("Sum() = %d, expected %d.", counter.Sum(), sum)
+	}
+	if counter.Get(1<<20) != 0 {
+		t.Fatalf("Expected a missing key to count as 0.")
+	}
+}
+
+func Test_Counter_DAM_Overflow_Modes(t *testing.T) {
+	wrap := dam.New_Counter_DAM(uint64(16), dam.OVERFLOW_MODE__WRAP)
+	saturate := dam.New_Counter_DAM(uint64(16), dam.OVERFLOW_MODE__SATURATE)
+
+	if got := wrap.Add(1, -1); got != math.MaxUint64 {
+		t.Fatalf("Wrapping 0 - 1 = %d, expected %d.", got, uint64(math.MaxUint64))
+	}
+	if got := wrap.Inc(1); got != 0 {
+		t.Fatalf("Wrapping max + 1 = %d, expected 0.", got)
+	}
+
+	if got := saturate.Add(1, -5); got != 0 {
+		t.Fatalf("Saturating 0 - 5 = %d, expected 0.", got)
+	}
+	saturate.Add(2, math.MaxInt64)
+	saturate.Add(2, math.MaxInt64)
+	if got := saturate.Add(2, math.MaxInt64); got != math.MaxUint64 {
+		t.Fatalf("Saturating 3 * MaxInt64 = %d, expected %d.", got, uint64(math.MaxUint64))
+	}
+	if got := saturate.Add(2, math.MinInt64); got != math.MaxUint64-(1<<63) {
+		t.Fatalf("Saturating max + MinInt64 = %d, expected %d.", got, uint64(math.MaxUint64-(1<<63)))
+	}
+
+	// The sum saturates too...
+	saturate.Add(3, math.MaxInt64)
+	saturate.Add(3, math.MaxInt64)
+	if got := saturate.Sum(); got != math.MaxUint64 {
+		t.Fatalf("Saturating Sum() = %d, expected %d.", got, uint64(math.MaxUint64))
+	}
+
+	expect_panic(t, "invalid overflow mode", func() {
+		dam.New_Counter_DAM(uint64(16), dam.T_Overflow_Mode(100))
+	})
+}
+
+func Test_Counter_DAM_Top_K(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	counter := dam.New_Counter_DAM(uint64(16), dam.OVERFLOW_MODE__WRAP)
+	for i := 0; i < 1<<14; i++ {
+		counter.Inc(uint64(rng.Intn(1<<10)) + 1)
+	}
+
+	// Rank everything by brute force, higher counts then lower keys first...
+	var expected []dam.T_Count[uint64]
+	for key, count := range counter.All() {
+		expected = append(expected, dam.T_Count[uint64]{Key: key, Count: count})
+	}
+	slices.SortFunc(expected, func(a, b dam.T_Count[uint64]) int {
+		if a.Count != b.Count {
+			if a.Count > b.Count {
+				return -1
+			}
+			return 1
+		}
+		if a.Key < b.Key {
+			return -1
+		}
+		return 1
+	})
+
+	for _, n := range []int{0, 1, 10, 100, len(expected), len(expected) + 10} {
+		got := counter.Top_K(n)
+		want := expected[:min(n, len(expected))]
+		if len(got) != len(want) {
+			t.Fatalf("Top_K(%d) returned %d counts, expected %d.", n, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("Top_K(%d)[%d] = %v, expected %v.", n, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func Test_Concurrent_Counter_DAM_Counts_Every_Add(t *testing.T) {
+	const num_goroutines = 8
+	const num_adds = 1 << 13
+	const num_keys = 256
+
+	for _, mode := range []dam.T_Overflow_Mode{dam.OVERFLOW_MODE__WRAP, dam.OVERFLOW_MODE__SATURATE} {
+		// Start tiny so that adds race with inserts and growth as well...
+		c := dam.New_Concurrent_Counter_DAM(uint64(4), 4, mode)
+
+		var wg sync.WaitGroup
+		for g := 0; g < num_goroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < num_adds; i++ {
+					key := uint64(i%num_keys) + 1
+					if (i/num_keys)%2 == 0 {
+						c.Inc(key)
+					} else {
+						c.Add(key, 3)
+						c.Add(key, -1)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		expected_per_key := uint64(num_goroutines * num_adds / num_keys * 3 / 2)
+		for key := uint64(1); key <= num_keys; key++ {
+			if got := c.Get(key); got != expected_per_key {
+				t.Fatalf("Mode %d: Get(%d) = %d, expected %d.", mode, key, got, expected_per_key)
+			}
+		}
+		if c.Len() != num_keys {
+			t.Fatalf("Mode %d: Len() = %d, expected %d.", mode, c.Len(), num_keys)
+		}
+		if c.Sum() != expected_per_key*num_keys {
+			t.Fatalf("Mode %d: Sum() = %d, expected %d.", mode, c.Sum(), expected_per_key*num_keys)
+		}
+		if top := c.Top_K(1); len(top) != 1 || top[0] != (dam.T_Count[uint64]{Key: 1, Count: expected_per_key}) {
+			t.Fatalf("Mode %d: Top_K(1) = %v.", mode, top)
+		}
+	}
+}
+
+func Benchmark_Random_Counter_Add(b *testing.B) {
+	const n = 1 << 16
+
+	rng := rand.New(rand.NewSource(1))
+	keys := make([]uint64, 1<<16)
+	for i := range keys {
+		keys[i] = uint64(rng.Intn(n)) + 1
+	}
+
+	b.Run("Builtin_Map", func(b *testing.B) {
+		builtin_map := make(map[uint64]uint64, n)
+		for i := 0; i < b.N; i++ {
+			builtin_map[keys[i&(len(keys)-1)]]++
+		}
+	})
+	b.Run("DAM_Get_Then_Set", func(b *testing.B) {
+		dam_map := dam.New[uint64, uint64](n)
+		for i := 0; i < b.N; i++ {
+			key := keys[i&(len(keys)-1)]
+			v, _ := dam_map.Get(key)
+			dam_map.Set(key, v+1)
+		}
+	})
+	b.Run("Counter_DAM", func(b *testing.B) {
+		counter := dam.New_Counter_DAM(uint64(n), dam.OVERFLOW_MODE__WRAP)
+		for i := 0; i < b.N; i++ {
+			counter.Inc(keys[i&(len(keys)-1)])
+		}
+	})
+}
+
+func Benchmark_Concurrent_Counter_Add(b *testing.B) {
+	const n = 1 << 12
+
+	c := dam.New_Concurrent_Counter_DAM(uint64(n), 0, dam.OVERFLOW_MODE__WRAP)
+	for k := uint64(1); k <= n; k++ {
+		c.Inc(k)
+	}
+
+	b.RunParallel(func(pb *testing.PB) {
+		rng := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			c.Inc(uint64(rng.Intn(n)) + 1)
+		}
+	})
+}
@@ -0,0 +1,252 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func test_DAM_Set_against_builtin_map(t *testing.T, options ...dam.T_Option[uint64, struct{}]) {
+	rng := rand.New(rand.NewSource(1))
+	builtin_map := make(map[uint64]struct{})
+	set := dam.New_DAM_Set(uint64(4), options...)
+
+	for i := 0; i < 1<<16; i++ {
+		key := uint64(rng.Intn(1 << 13))
+		_, present := builtin_map[key]
+		switch rng.Intn(3) {
+		case 0:
+			delete(builtin_map, key)
+			if got := set.Remove(key); got != present {
+				t.Fatalf("Remove(%d) = %v, expected %v.", key, got, present)
+			}
+		case 1:
+			if got := set.Has(key); got != present {
+				t.Fatalf("Has(%d) = %v, expected %v.", key, got, present)
+			}
+		default:
+			builtin_map[key] = struct{}{}
+			if got := set.Add(key); got == present {
+				t.Fatalf("Add(%d) = %v, expected %v.", key, got, !present)
+			}
+		}
+	}
+
+	keys := func(yield func(uint64, struct{}) bool) {
+		for key := range set.All() {
+			if !yield(key, struct{}{}) {
+				return
+			}
+		}
+	}
+	check_all_against_builtin_map(t, set.Len(), keys, builtin_map)
+}
+
+func Test_DAM_Set_Against_Builtin_Map(t *testing.T) {
+	test_DAM_Set_against_builtin_map(t)
+}
+
+func Test_DAM_Set_Against_Builtin_Map_Ordered_Shift_Range_Reduction(t *testing.T) {
+	test_DAM_Set_against_builtin_map(t,
+		dam.With_Delete_Strategy[uint64, struct{}](dam.DELETE_STRATEGY__ORDERED_SHIFT),
+		dam.With_Range_Reduction[uint64, struct{}](true),
+		dam.With_Hash_Mixer[uint64, struct{}](dam.HASH_MIXER__MURMUR3),
+	)
+}
+
+func Test_DAM_Set_Algebra(t *testing.T) {
+	a := dam.New_DAM_Set(uint64(16))
+	b := dam.New_DAM_Set(uint64(1024), dam.With_Hash_Mixer[uint64, struct{}](dam.HASH_MIXER__SPLITMIX64))
+
+	// Multiples of 2 and multiples of 3...
+	for k := uint64(0); k < 3000; k += 2 {
+		a.Add(k)
+	}
+	for k := uint64(0); k < 3000; k += 3 {
+		b.Add(k)
+	}
+
+	check := func(name string, s *dam.DAM_Set[uint64], expected func(uint64) bool) {
+		n := 0
+		for k := uint64(0); k < 3000; k++ {
+			if s.Has(k) != expected(k) {
+				t.Fatalf("%s: Has(%d) = %v, expected %v.", name, k, s.Has(k), expected(k))
+			}
+			if expected(k) {
+				n++
+			}
+		}
+		if s.Len() != n {
+			t.Fatalf("%s: Len() = %d, expected %d.", name, s.Len(), n)
+		}
+	}
+
+	check("Union", a.Union(b), func(k uint64) bool { return k%2 == 0 || k%3 == 0 })
+	check("Intersection", a.Intersection(b), func(k uint64) bool { return k%6 == 0 })
+	check("Intersection reversed", b.Intersection(a), func(k uint64) bool { return k%6 == 0 })
+	check("Difference", a.Difference(b), func(k uint64) bool { return k%2 == 0 && k%3 != 0 })
+	check("Difference reversed", b.Difference(a), func(k uint64) bool { return k%3 == 0 && k%2 != 0 })
+
+	// The operands are left untouched...
+	check("a", a, func(k uint64) bool { return k%2 == 0 })
+	check("b", b, func(k uint64) bool { return k%3 == 0 })
+
+	empty := dam.New_DAM_Set(uint64(0))
+	check("Union with empty", empty.Union(a), func(k uint64) bool { return k%2 == 0 })
+	check("Intersection with empty", a.Intersection(empty), func(uint64) bool { return false })
+}
+
+func Test_DAM_Set_Small_Key_Types(t *testing.T) {
+	set := dam.New_DAM_Set(uint8(0))
+	for k := 0; k < 256; k++ {
+		set.Add(uint8(k))
+	}
+	if set.Len() != 256 {
+		t.Fatalf("Len() = %d, expected 256.", set.Len())
+	}
+	if set.Enquire_Number_Of_Buckets() > 256 {
+		t.Fatalf("Expected at most 256 buckets, got %d.", set.Enquire_Number_Of_Buckets())
+	}
+	if u := set.Union(set); u.Len() != 256 {
+		t.Fatalf("Union with itself has %d keys, expected 256.", u.Len())
+	}
+
+	set.Clear()
+	if set.Len() != 0 || set.Has(7) {
+		t.Fatalf("Expected the set to be empty after Clear.")
+	}
+}
+
+func Test_DAM_Set_Single_Bucket_Holds_128_Bytes_Of_Keys(t *testing.T) {
+	// 16 uint64 keys fit where a `DAM[uint64, struct{}]` only fits 8 padded entries...
+	if n := dam.New_DAM_Set(uint64(16)).Enquire_Number_Of_Buckets(); n != 1 {
+		t.Fatalf("A set sized for 16 keys has %d buckets, expected 1.", n)
+	}
+	if n := dam.New_DAM_Set(uint64(17)).Enquire_Number_Of_Buckets(); n == 1 {
+		t.Fatalf("A set sized for 17 keys has a single bucket.")
+	}
+
+	set := dam.New_DAM_Set(uint64(0))
+	for k := uint64(0); k < 16; k++ {
+		set.Add(k)
+	}
+	if n := set.Enquire_Number_Of_Buckets(); n != 1 {
+		t.Fatalf("A set grew to %d buckets for 16 keys, expected 1.", n)
+	}
+	if n := set.Intersection(set).Enquire_Number_Of_Buckets(); n != 1 {
+		t.Fatalf("The intersection of a 16 key set with itself has %d buckets, expected 1.", n)
+	}
+}
+
+func Test_DAM_Set_Iteration_Detects_Structural_Changes(t *testing.T) {
+	set := dam.New_DAM_Set(uint64(64))
+	for k := uint64(0); k < 64; k++ {
+		set.Add(k)
+	}
+
+	// Adding a present key is fine...
+	for key := range set.All() {
+		set.Add(key)
+	}
+
+	expect_panic(t, "adding during iteration", func() {
+		for key := range set.All() {
+			set.Add(key + 1000)
+		}
+	})
+	expect_panic(t, "removing during iteration", func() {
+		for key := range set.All() {
+			set.Remove(key)
+		}
+	})
+}
+
+func Test_DAM_Set_Rejects_Incremental_Resize(t *testing.T) {
+	expect_panic(t, "incremental resize", func() {
+		dam.New_DAM_Set(uint64(64), dam.With_Incremental_Resize[uint64, struct{}](4))
+	})
+}
+
+// The operations every set is benchmarked with.
+type t_set struct {
+	name string
+	add  func(uint64)
+	has  func(uint64) bool
+}
+
+func new_sets(n int) []t_set {
+	builtin_map := make(map[uint64]struct{}, n)
+	dam_map := dam.New[uint64, struct{}](uint64(n))
+	set := dam.New_DAM_Set(uint64(n))
+
+	return []t_set{
+		{
+			"Builtin_Map",
+			func(k uint64) { builtin_map[k] = struct{}{} },
+			func(k uint64) bool { _, ok := builtin_map[k]; return ok },
+		},
+		{
+			"DAM_Struct",
+			func(k uint64) { dam_map.Set(k, struct{}{}) },
+			func(k uint64) bool { _, ok := dam_map.Get(k); return ok },
+		},
+		{"DAM_Set", func(k uint64) { set.Add(k) }, set.Has},
+	}
+}
+
+func Benchmark_Random_Set_Add(b *testing.B) {
+	const n = 1 << 20
+
+	keys := generate_random_keys(n)
+	for index, named := range new_sets(0) {
+		b.Run(named.name, func(b *testing.B) {
+			var before, after runtime.MemStats
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				b.StartTimer()
+
+				// Start small so that growth is part of the cost...
+				set := new_sets(8)[index]
+				for _, k := range keys {
+					set.add(uint64(k))
+				}
+
+				b.StopTimer()
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/n, "bytes/key")
+				runtime.KeepAlive(set)
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/key")
+		})
+	}
+}
+
+func Benchmark_Random_Set_Has(b *testing.B) {
+	const n = 1 << 20
+
+	keys := generate_random_keys(n)
+	for _, set := range new_sets(n) {
+		for _, k := range keys {
+			set.add(uint64(k))
+		}
+
+		b.Run(set.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if !set.has(uint64(keys[i&(n-1)])) {
+					panic("Key not found.")
+				}
+			}
+		})
+	}
+}
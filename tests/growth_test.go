@@ -0,0 +1,116 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Auto_Growth_Keeps_Target_Load(t *testing.T) {
+	profiles := []struct {
+		profile dam.T_Performance_Profile
+		target  float64
+	}{
+		{dam.PERFORMANCE_PROFILE__FAST, 2},
+		{dam.PERFORMANCE_PROFILE__NORMAL, 4},
+		{dam.PERFORMANCE_PROFILE__SAVE_MEMORY, 8},
+	}
+
+	for _, p := range profiles {
+		const expected = 1024
+		const n = expected * 64
+
+		dam_map := dam.New(uint64(expected), dam.With_Performance_Profile[uint64, uint64](p.profile))
+		initial_num_buckets := dam_map.Enquire_Number_Of_Buckets()
+
+		for i := uint64(0); i < n; i++ {
+			dam_map.Set(i+1, i)
+		}
+
+		s := dam_map.Stats()
+		if s.Num_Buckets <= initial_num_buckets {
+			t.Fatalf("Profile %d: bucket count did not grow from %d.", p.profile, initial_num_buckets)
+		}
+		if s.Mean_Entries_Per_Bucket > p.target {
+			t.Fatalf("Profile %d: mean bucket length %f exceeds target %f.", p.profile, s.Mean_Entries_Per_Bucket, p.target)
+		}
+		if s.Num_Entries != n || dam_map.Len() != n {
+			t.Fatalf("Profile %d: expected %d entries, got %d.", p.profile, n, s.Num_Entries)
+		}
+
+		for i := uint64(0); i < n; i++ {
+			if v, ok := dam_map.Get(i + 1); !ok || v != i {
+				t.Fatalf("Profile %d: Get(%d) = (%d, %v) after growth.", p.profile, i+1, v, ok)
+			}
+		}
+	}
+}
+
+func Test_Auto_Growth_With_Hash_Mixer_Against_Builtin_Map(t *testing.T) {
+	const n = 1024 * 32
+
+	dam_map := dam.New(uint64(64), dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__MURMUR3))
+	run_against_builtin_map(t, dam_map, make(map[uint64]uint64), t_workload[uint64]{
+		num_ops:      n,
+		random_key:   func(rng *rand.Rand) uint64 { return uint64(rng.Intn(n)+1) * 1024 },
+		delete_ratio: 4,
+	})
+}
+
+func Test_Auto_Growth_Disabled(t *testing.T) {
+	const expected = 1024
+
+	dam_map := dam.New(uint64(expected), dam.With_Auto_Growth[uint64, uint64](false))
+	num_buckets := dam_map.Enquire_Number_Of_Buckets()
+
+	for i := uint64(0); i < expected*16; i++ {
+		dam_map.Set(i+1, i)
+	}
+
+	if dam_map.Enquire_Number_Of_Buckets() != num_buckets {
+		t.Fatalf("Bucket count changed from %d to %d with growth disabled.", num_buckets, dam_map.Enquire_Number_Of_Buckets())
+	}
+	if v, ok := dam_map.Get(expected * 16); !ok || v != expected*16-1 {
+		t.Fatalf("Get(%d) = (%d, %v).", expected*16, v, ok)
+	}
+}
+
+// Size the map for only 1024 entries and let it grow to b.N...
+func Benchmark_Linear_DAM_Set_Auto_Growth(b *testing.B) {
+	dam_map := dam.New(
+		uint64(1024), dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__NORMAL),
+	)
+	b.ResetTimer()
+	for i := uint64(0); i < uint64(b.N); i++ {
+		dam_map.Set(i+1, i)
+	}
+}
+
+func Benchmark_Random_DAM_Get_After_Auto_Growth(b *testing.B) {
+	dam_map := dam.New(
+		uint64(1024), dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__NORMAL),
+	)
+
+	for i := 0; i < b.N; i++ {
+		dam_map.Set(uint64(i+1), uint64(i))
+	}
+
+	keys := generate_random_keys(b.N)
+
+	var t uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, ok := dam_map.Get(uint64(keys[i]))
+		if ok {
+			t += x
+		} else {
+			panic("Key not found.")
+		}
+	}
+}
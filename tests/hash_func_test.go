@@ -0,0 +1,135 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+// Our IDs keep a shard number in their low bits, which is constant for a whole map.
+const shard_bits = 8
+
+func shard_id(i uint64) uint64 {
+	return (i << shard_bits) | 7
+}
+
+func strip_shard(k uint64) uint64 {
+	return k >> shard_bits
+}
+
+func Test_Hash_Func_Drives_Bucket_Selection(t *testing.T) {
+	const n = 1024 * 16
+
+	without := dam.New[uint64, uint64](n)
+	with := dam.New(uint64(n), dam.With_Hash_Func[uint64, uint64](strip_shard))
+	for i := uint64(0); i < n; i++ {
+		without.Set(shard_id(i), i)
+		with.Set(shard_id(i), i)
+	}
+
+	// Only one in every 2^shard_bits buckets is used when the low bits are constant...
+	s := without.Stats()
+	if non_empty := s.Num_Buckets - s.Bucket_Length_Histogram[0]; non_empty != s.Num_Buckets>>shard_bits {
+		t.Fatalf("Expected %d non-empty buckets without a hash func, got %d.", s.Num_Buckets>>shard_bits, non_empty)
+	}
+
+	s = with.Stats()
+	expected := uint64(n) / s.Num_Buckets
+	if s.Min_Entries_Per_Bucket != expected || s.Max_Entries_Per_Bucket != expected {
+		t.Fatalf(
+			"Expected %d entries per bucket with a hash func, got min %d and max %d.",
+			expected, s.Min_Entries_Per_Bucket, s.Max_Entries_Per_Bucket,
+		)
+	}
+}
+
+func Test_Hash_Func_Against_Builtin_Map(t *testing.T) {
+	const n = 1024 * 4
+
+	dam_map := dam.New(uint64(n), dam.With_Hash_Func[uint64, uint64](strip_shard))
+	run_against_builtin_map(t, dam_map, make(map[uint64]uint64), t_workload[uint64]{
+		num_ops:      n * 8,
+		random_key:   func(rng *rand.Rand) uint64 { return shard_id(uint64(rng.Intn(n * 2))) },
+		delete_ratio: 3,
+	})
+}
+
+func bench_random_DAM_get_skewed(b *testing.B, options ...dam.T_Option[uint64, uint64]) {
+	dam_map := dam.New(uint64(b.N), options...)
+
+	for i := 0; i < b.N; i++ {
+		dam_map.Set(shard_id(uint64(i)), uint64(i))
+	}
+
+	keys := generate_random_keys(b.N)
+
+	var t uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, ok := dam_map.Get(shard_id(uint64(keys[i] - 1)))
+		if ok {
+			t += x
+		} else {
+			panic("Key not found.")
+		}
+	}
+}
+
+func Benchmark_Random_DAM_Get_Without_Hash_Func(b *testing.B) {
+	dam_map := dam.New[uint64, uint64](uint64(b.N))
+
+	for i := 0; i < b.N; i++ {
+		dam_map.Set(uint64(i+1), uint64(i))
+	}
+
+	keys := generate_random_keys(b.N)
+
+	var t uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, ok := dam_map.Get(uint64(keys[i]))
+		if ok {
+			t += x
+		} else {
+			panic("Key not found.")
+		}
+	}
+}
+
+func Benchmark_Random_DAM_Get_With_Hash_Func(b *testing.B) {
+	dam_map := dam.New(
+		uint64(b.N),
+		dam.With_Hash_Func[uint64, uint64](func(k uint64) uint64 { return k }),
+	)
+
+	for i := 0; i < b.N; i++ {
+		dam_map.Set(uint64(i+1), uint64(i))
+	}
+
+	keys := generate_random_keys(b.N)
+
+	var t uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, ok := dam_map.Get(uint64(keys[i]))
+		if ok {
+			t += x
+		} else {
+			panic("Key not found.")
+		}
+	}
+}
+
+func Benchmark_Random_DAM_Get_Skewed_Without_Hash_Func(b *testing.B) {
+	bench_random_DAM_get_skewed(b)
+}
+
+func Benchmark_Random_DAM_Get_Skewed_With_Hash_Func(b *testing.B) {
+	bench_random_DAM_get_skewed(b, dam.With_Hash_Func[uint64, uint64](strip_shard))
+}
@@ -0,0 +1,172 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+type t_mixer_case struct {
+	name   string
+	option dam.T_Option[uint64, uint64]
+}
+
+var mixer_cases = []t_mixer_case{
+	{"Identity", dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__IDENTITY)},
+	{"Fibonacci", dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__FIBONACCI)},
+	{"Murmur3", dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__MURMUR3)},
+	{"Splitmix64", dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__SPLITMIX64)},
+	{"Seeded", dam.With_Seeded_Hash_Mixer[uint64, uint64](42)},
+}
+
+type t_key_pattern struct {
+	name string
+	gen  func(n int) []uint64
+}
+
+var key_patterns = []t_key_pattern{
+	{"Sequential", func(n int) []uint64 {
+		keys := make([]uint64, n)
+		for i := range keys {
+			keys[i] = uint64(i + 1)
+		}
+		return keys
+	}},
+	{"Stride_1024", func(n int) []uint64 {
+		keys := make([]uint64, n)
+		for i := range keys {
+			keys[i] = uint64(i+1) * 1024
+		}
+		return keys
+	}},
+	// Millisecond timestamp, then a constant worker ID, then a short per-millisecond sequence...
+	{"Snowflake", func(n int) []uint64 {
+		const epoch_ms = 1_700_000_000_000
+		const worker = 5
+		keys := make([]uint64, n)
+		for i := range keys {
+			keys[i] = (uint64(epoch_ms+i/4) << 22) | (worker << 12) | uint64(i%4)
+		}
+		return keys
+	}},
+	{"Random", func(n int) []uint64 {
+		rng := rand.New(rand.NewSource(1))
+		keys := make([]uint64, n)
+		for i := range keys {
+			keys[i] = rng.Uint64()>>1 + 1
+		}
+		return keys
+	}},
+}
+
+func bucket_length_variance(s dam.T_Stats) float64 {
+	var sum float64
+	for length, count := range s.Bucket_Length_Histogram {
+		d := float64(length) - s.Mean_Entries_Per_Bucket
+		sum += d * d * float64(count)
+	}
+	return sum / float64(s.Num_Buckets)
+}
+
+func Test_Mixer_Reference_Values(t *testing.T) {
+	cases := []struct {
+		name     string
+		got      uint64
+		expected uint64
+	}{
+		{"Mix_Identity(7)", dam.Mix_Identity(7), 7},
+		{"Mix_Murmur3(0)", dam.Mix_Murmur3(0), 0},
+		{"Mix_Murmur3(1)", dam.Mix_Murmur3(1), 0xb456bcfc34c2cb2c},
+		{"Mix_Murmur3(0xdeadbeef)", dam.Mix_Murmur3(0xdeadbeef), 0xd24bd59f862a1dac},
+		// The first output of a splitmix64 generator seeded with 0...
+		{"Mix_Splitmix64(0)", dam.Mix_Splitmix64(0), 0xe220a8397b1dcdaf},
+		{"Mix_Splitmix64(1)", dam.Mix_Splitmix64(1), 0x910a2dec89025cc1},
+	}
+	for _, c := range cases {
+		if c.got != c.expected {
+			t.Errorf("%s = %#x, expected %#x.", c.name, c.got, c.expected)
+		}
+	}
+
+	if dam.Mix_Seeded(1, 1) == dam.Mix_Seeded(1, 2) {
+		t.Errorf("Different seeds produced the same hash.")
+	}
+}
+
+func Test_Mixers_Are_Bijective_On_Sample(t *testing.T) {
+	mixers := map[string]func(uint64) uint64{
+		"Fibonacci":  dam.Hash_Mixer_Func(dam.HASH_MIXER__FIBONACCI),
+		"Murmur3":    dam.Hash_Mixer_Func(dam.HASH_MIXER__MURMUR3),
+		"Splitmix64": dam.Hash_Mixer_Func(dam.HASH_MIXER__SPLITMIX64),
+		"Seeded":     func(k uint64) uint64 { return dam.Mix_Seeded(k, 42) },
+	}
+	for name, f := range mixers {
+		seen := make(map[uint64]struct{}, 1<<16)
+		for k := uint64(0); k < 1<<16; k++ {
+			h := f(k * 1024)
+			if _, ok := seen[h]; ok {
+				t.Fatalf("%s collided on key %d.", name, k*1024)
+			}
+			seen[h] = struct{}{}
+		}
+	}
+}
+
+func Test_Mixers_Spread_Skewed_Keys(t *testing.T) {
+	const n = 1024 * 16
+
+	for _, pattern := range key_patterns[1:3] {
+		keys := pattern.gen(n)
+		for _, mixer := range mixer_cases[1:] {
+			dam_map := dam.New(uint64(n), mixer.option)
+			for i, k := range keys {
+				dam_map.Set(k, uint64(i))
+			}
+
+			// A good mixer behaves like random placement, which rarely exceeds 4x the mean...
+			s := dam_map.Stats()
+			if float64(s.Max_Entries_Per_Bucket) > 4*s.Mean_Entries_Per_Bucket {
+				t.Errorf(
+					"%s/%s: max bucket length %d for a mean of %f.",
+					pattern.name, mixer.name, s.Max_Entries_Per_Bucket, s.Mean_Entries_Per_Bucket,
+				)
+			}
+
+			for i, k := range keys {
+				if v, ok := dam_map.Get(k); !ok || v != uint64(i) {
+					t.Fatalf("%s/%s: Get(%d) = (%d, %v).", pattern.name, mixer.name, k, v, ok)
+				}
+			}
+		}
+	}
+}
+
+// Reports the variance of the bucket lengths for every mixer on every key pattern.
+// Lower is better, random placement gives a variance close to the mean.
+func Benchmark_Hash_Mixer_Distribution(b *testing.B) {
+	const n = 1024 * 64
+
+	for _, pattern := range key_patterns {
+		keys := pattern.gen(n)
+		for _, mixer := range mixer_cases {
+			b.Run(pattern.name+"/"+mixer.name, func(b *testing.B) {
+				var s dam.T_Stats
+				for i := 0; i < b.N; i++ {
+					dam_map := dam.New(uint64(n), mixer.option)
+					for j, k := range keys {
+						dam_map.Set(k, uint64(j))
+					}
+					s = dam_map.Stats()
+				}
+				b.ReportMetric(bucket_length_variance(s), "variance")
+				b.ReportMetric(float64(s.Max_Entries_Per_Bucket), "max-len")
+			})
+		}
+	}
+}
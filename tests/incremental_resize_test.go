@@ -0,0 +1,205 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+// Fill the map until the next insert starts an incremental resize.
+func fill_until_resizing(t *testing.T, dam_map *dam.DAM[uint64, uint64], builtin_map map[uint64]uint64) uint64 {
+	t.Helper()
+	key := uint64(1)
+	for !dam_map.Enquire_Is_Resizing() {
+		dam_map.Set(key, key*3)
+		builtin_map[key] = key * 3
+		key++
+		if key > 1<<20 {
+			t.Fatalf("Map never started resizing.")
+		}
+	}
+	return key
+}
+
+func Test_Incremental_Resize_Get_Mid_Migration(t *testing.T) {
+	builtin_map := make(map[uint64]uint64)
+	dam_map := dam.New(uint64(1024*8), dam.With_Incremental_Resize[uint64, uint64](1))
+	fill_until_resizing(t, dam_map, builtin_map)
+
+	// Every Get migrates a single bucket, so each lookup sees a different migration point...
+	keys := make([]uint64, 0, len(builtin_map))
+	for k := range builtin_map {
+		keys = append(keys, k)
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(keys), func(i, j int) {
+		keys[i], keys[j] = keys[j], keys[i]
+	})
+
+	num_checked_mid_migration := 0
+	for _, k := range keys {
+		if dam_map.Enquire_Is_Resizing() {
+			num_checked_mid_migration++
+		}
+		if v, ok := dam_map.Get(k); !ok || v != builtin_map[k] {
+			t.Fatalf("Get(%d) = (%d, %v) mid-migration, expected (%d, true).", k, v, ok, builtin_map[k])
+		}
+	}
+
+	if num_checked_mid_migration < 1000 {
+		t.Fatalf("Only %d lookups happened mid-migration.", num_checked_mid_migration)
+	}
+	if dam_map.Enquire_Is_Resizing() {
+		t.Fatalf("Migration did not finish after %d lookups.", len(keys))
+	}
+}
+
+func Test_Incremental_Resize_Against_Builtin_Map(t *testing.T) {
+	const n = 1024 * 64
+
+	dam_map := dam.New(
+		uint64(1024),
+		dam.With_Incremental_Resize[uint64, uint64](1),
+		dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__SPLITMIX64),
+	)
+
+	// A key is drawn once per operation, so this counts the operations that happen mid-migration...
+	num_ops_mid_migration := 0
+	run_against_builtin_map(t, dam_map, make(map[uint64]uint64), t_workload[uint64]{
+		num_ops: n,
+		random_key: func(rng *rand.Rand) uint64 {
+			if dam_map.Enquire_Is_Resizing() {
+				num_ops_mid_migration++
+			}
+			return uint64(rng.Intn(n) + 1)
+		},
+		delete_ratio: 4,
+	})
+
+	if num_ops_mid_migration == 0 {
+		t.Fatalf("No operation happened mid-migration.")
+	}
+}
+
+func Test_Incremental_Resize_Iteration_And_Stats_Mid_Migration(t *testing.T) {
+	builtin_map := make(map[uint64]uint64)
+	dam_map := dam.New(uint64(1024*8), dam.With_Incremental_Resize[uint64, uint64](1))
+	fill_until_resizing(t, dam_map, builtin_map)
+
+	// Overwriting existing keys during iteration must not advance the migration...
+	seen := make(map[uint64]uint64)
+	for k, v := range dam_map.All() {
+		if _, ok := seen[k]; ok {
+			t.Fatalf("Key %d visited twice mid-migration.", k)
+		}
+		seen[k] = v
+		dam_map.Set(k, v+1)
+	}
+	if len(seen) != len(builtin_map) {
+		t.Fatalf("Visited %d entries mid-migration, expected %d.", len(seen), len(builtin_map))
+	}
+	if !dam_map.Enquire_Is_Resizing() {
+		t.Fatalf("Migration advanced during iteration.")
+	}
+
+	if s := dam_map.Stats(); s.Num_Entries != uint64(len(builtin_map)) {
+		t.Fatalf("Stats reported %d entries mid-migration, expected %d.", s.Num_Entries, len(builtin_map))
+	}
+
+	dam_map.Finish_Resize()
+	if dam_map.Enquire_Is_Resizing() {
+		t.Fatalf("Finish_Resize did not finish the migration.")
+	}
+	for k, v := range builtin_map {
+		if got, ok := dam_map.Get(k); !ok || got != v+1 {
+			t.Fatalf("Get(%d) = (%d, %v), expected (%d, true).", k, got, ok, v+1)
+		}
+	}
+}
+
+func Test_Incremental_Resize_Finish_Resize_During_Iteration(t *testing.T) {
+	builtin_map := make(map[uint64]uint64)
+	dam_map := dam.New(uint64(1024*8), dam.With_Incremental_Resize[uint64, uint64](1))
+	fill_until_resizing(t, dam_map, builtin_map)
+
+	// Migrating under the iterator would move entries it has not visited yet into buckets it still has to visit...
+	seen := make(map[uint64]struct{})
+	for k := range dam_map.Keys() {
+		if _, ok := seen[k]; ok {
+			t.Fatalf("Keys yielded %d twice.", k)
+		}
+		seen[k] = struct{}{}
+		dam_map.Finish_Resize()
+	}
+	check_all_against_builtin_map(t, dam_map.Len(), dam_map.All(), builtin_map)
+	if !dam_map.Enquire_Is_Resizing() {
+		t.Fatalf("Finish_Resize migrated during iteration.")
+	}
+
+	dam_map.Finish_Resize()
+	if dam_map.Enquire_Is_Resizing() {
+		t.Fatalf("Finish_Resize did not finish the migration after iteration.")
+	}
+	check_against_builtin_map(t, dam_map, builtin_map)
+}
+
+func Test_Incremental_Resize_Clear_Mid_Migration(t *testing.T) {
+	builtin_map := make(map[uint64]uint64)
+	dam_map := dam.New(uint64(1024*8), dam.With_Incremental_Resize[uint64, uint64](1))
+	next_key := fill_until_resizing(t, dam_map, builtin_map)
+
+	dam_map.Clear()
+	if dam_map.Enquire_Is_Resizing() || dam_map.Len() != 0 {
+		t.Fatalf("Clear left the map resizing or non-empty.")
+	}
+	for k := uint64(1); k < next_key; k++ {
+		if _, ok := dam_map.Get(k); ok {
+			t.Fatalf("Key %d still present after Clear.", k)
+		}
+	}
+}
+
+// Size the map for only 1024 entries and let it grow to b.N a few buckets at a time...
+func Benchmark_Linear_DAM_Set_Incremental_Resize(b *testing.B) {
+	dam_map := dam.New(
+		uint64(1024),
+		dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__NORMAL),
+		dam.With_Incremental_Resize[uint64, uint64](4),
+	)
+	b.ResetTimer()
+	for i := uint64(0); i < uint64(b.N); i++ {
+		dam_map.Set(i+1, i)
+	}
+}
+
+// Reports the slowest single `Set` while growing from 1024 to b.N entries.
+func bench_worst_case_DAM_set(b *testing.B, buckets_per_step uint64) {
+	dam_map := dam.New(
+		uint64(1024),
+		dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__NORMAL),
+		dam.With_Incremental_Resize[uint64, uint64](buckets_per_step),
+	)
+
+	var worst time.Duration
+	b.ResetTimer()
+	for i := uint64(0); i < uint64(b.N); i++ {
+		start := time.Now()
+		dam_map.Set(i+1, i)
+		worst = max(worst, time.Since(start))
+	}
+	b.ReportMetric(float64(worst.Nanoseconds()), "worst-ns")
+}
+
+func Benchmark_Worst_Case_DAM_Set_Stop_The_World_Resize(b *testing.B) {
+	bench_worst_case_DAM_set(b, 0)
+}
+
+func Benchmark_Worst_Case_DAM_Set_Incremental_Resize(b *testing.B) {
+	bench_worst_case_DAM_set(b, 4)
+}
@@ -0,0 +1,129 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func new_filled_DAM(n uint64) *dam.DAM[uint64, uint64] {
+	dam_map := dam.New[uint64, uint64](n)
+	for i := uint64(0); i < n; i++ {
+		dam_map.Set(i+1, i)
+	}
+	return dam_map
+}
+
+func expect_panic(t *testing.T, what string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected %s to panic.", what)
+		}
+	}()
+	f()
+}
+
+func Test_All_Visits_Every_Entry_Once(t *testing.T) {
+	const n = 1024
+	dam_map := new_filled_DAM(n)
+
+	seen := make(map[uint64]uint64)
+	for k, v := range dam_map.All() {
+		if _, ok := seen[k]; ok {
+			t.Fatalf("Key %d visited twice.", k)
+		}
+		seen[k] = v
+	}
+
+	if len(seen) != n {
+		t.Fatalf("Visited %d entries, expected %d.", len(seen), n)
+	}
+	for k, v := range seen {
+		if v != k-1 {
+			t.Fatalf("Key %d yielded value %d, expected %d.", k, v, k-1)
+		}
+	}
+}
+
+func Test_Keys_And_Values(t *testing.T) {
+	const n = 1024
+	dam_map := new_filled_DAM(n)
+
+	var key_sum, value_sum, num_keys, num_values uint64
+	for k := range dam_map.Keys() {
+		key_sum += k
+		num_keys++
+	}
+	for v := range dam_map.Values() {
+		value_sum += v
+		num_values++
+	}
+
+	if num_keys != n || num_values != n {
+		t.Fatalf("Got %d keys and %d values, expected %d of each.", num_keys, num_values, n)
+	}
+	if key_sum != n*(n+1)/2 || value_sum != n*(n-1)/2 {
+		t.Fatalf("Got key sum %d and value sum %d.", key_sum, value_sum)
+	}
+}
+
+func Test_All_Early_Break(t *testing.T) {
+	dam_map := new_filled_DAM(1024)
+
+	visited := 0
+	for range dam_map.All() {
+		visited++
+		if visited == 10 {
+			break
+		}
+	}
+	if visited != 10 {
+		t.Fatalf("Visited %d entries, expected 10.", visited)
+	}
+}
+
+func Test_All_Allows_Overwriting_Existing_Keys(t *testing.T) {
+	const n = 1024
+	dam_map := new_filled_DAM(n)
+
+	for k, v := range dam_map.All() {
+		dam_map.Set(k, v+1)
+	}
+
+	for i := uint64(0); i < n; i++ {
+		if v, _ := dam_map.Get(i + 1); v != i+1 {
+			t.Fatalf("Get(%d) = %d, expected %d.", i+1, v, i+1)
+		}
+	}
+}
+
+func Test_All_Detects_Structural_Changes(t *testing.T) {
+	const n = 1024
+
+	dam_map := new_filled_DAM(n)
+	expect_panic(t, "inserting during iteration", func() {
+		for range dam_map.All() {
+			dam_map.Set(n+1, 0)
+		}
+	})
+
+	dam_map = new_filled_DAM(n)
+	expect_panic(t, "deleting during iteration", func() {
+		for k := range dam_map.Keys() {
+			dam_map.Delete(k)
+		}
+	})
+
+	dam_map = new_filled_DAM(n)
+	expect_panic(t, "clearing during iteration", func() {
+		for range dam_map.Values() {
+			dam_map.Clear()
+		}
+	})
+}
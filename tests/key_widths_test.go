@@ -0,0 +1,148 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+// Runs the same checks for one key type: construction, set/get/delete against the built-in map,
+// growth from an underestimated size, iteration and stats.
+func run_key_width_suite[KT dam.I_Positive_Integer](t *testing.T, max_key KT) {
+	profiles := []dam.T_Performance_Profile{
+		dam.PERFORMANCE_PROFILE__FAST,
+		dam.PERFORMANCE_PROFILE__NORMAL,
+		dam.PERFORMANCE_PROFILE__SAVE_MEMORY,
+	}
+
+	cases := []struct {
+		name     string
+		expected KT
+		options  []dam.T_Option[KT, uint64]
+	}{
+		{"Max_Key", KT(min(uint64(max_key), 1<<16)), nil},
+		{"Underestimated", 16, nil},
+		{"Mixer", 16, []dam.T_Option[KT, uint64]{dam.With_Hash_Mixer[KT, uint64](dam.HASH_MIXER__MURMUR3)}},
+		{"Range", 100, []dam.T_Option[KT, uint64]{dam.With_Range_Reduction[KT, uint64](true)}},
+		{"Incremental", 16, []dam.T_Option[KT, uint64]{dam.With_Incremental_Resize[KT, uint64](1)}},
+	}
+
+	for _, profile := range profiles {
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%s/%d", c.name, profile), func(t *testing.T) {
+				options := append([]dam.T_Option[KT, uint64]{dam.With_Performance_Profile[KT, uint64](profile)}, c.options...)
+				dam_map := dam.New(c.expected, options...)
+				builtin_map := make(map[KT]uint64)
+
+				// Keys are drawn from the whole range of KT, using at most 64K distinct ones...
+				rng := rand.New(rand.NewSource(2))
+				pool := make([]KT, min(uint64(max_key), 1<<16))
+				for i := range pool {
+					pool[i] = KT(rng.Uint64()%uint64(max_key)) + 1
+				}
+
+				run_against_builtin_map(t, dam_map, builtin_map, t_workload[KT]{
+					num_ops:      len(pool) * 4,
+					random_key:   func(rng *rand.Rand) KT { return pool[rng.Intn(len(pool))] },
+					delete_ratio: 4,
+				})
+
+				s := dam_map.Stats()
+				if s.Num_Entries != uint64(len(builtin_map)) || s.Num_Buckets == 0 {
+					t.Fatalf("Stats reported %d entries in %d buckets.", s.Num_Entries, s.Num_Buckets)
+				}
+			})
+		}
+	}
+}
+
+func Test_Key_Width_Uint8(t *testing.T) {
+	run_key_width_suite[uint8](t, 255)
+}
+
+func Test_Key_Width_Uint16(t *testing.T) {
+	run_key_width_suite[uint16](t, 65535)
+}
+
+func Test_Key_Width_Uint32(t *testing.T) {
+	run_key_width_suite[uint32](t, 4294967295)
+}
+
+func Test_Key_Width_Uint64(t *testing.T) {
+	run_key_width_suite[uint64](t, 18446744073709551615)
+}
+
+func Test_Uint8_Buckets_Do_Not_Overflow(t *testing.T) {
+	dam_map := dam.New(
+		uint8(255), dam.With_Performance_Profile[uint8, uint8](dam.PERFORMANCE_PROFILE__FAST),
+	)
+	if n := dam_map.Enquire_Number_Of_Buckets(); n != 128 {
+		t.Fatalf("Expected 128 buckets for 255 uint8 keys, got %d.", n)
+	}
+
+	for k := 1; k <= 255; k++ {
+		dam_map.Set(uint8(k), uint8(k))
+	}
+
+	// Never grow past one bucket per possible key...
+	if n := dam_map.Enquire_Number_Of_Buckets(); n > 256 {
+		t.Fatalf("Grew to %d buckets for uint8 keys.", n)
+	}
+	for k := 1; k <= 255; k++ {
+		if v, ok := dam_map.Get(uint8(k)); !ok || v != uint8(k) {
+			t.Fatalf("Get(%d) = (%d, %v).", k, v, ok)
+		}
+	}
+}
+
+type t_id8 uint8
+type t_id16 uint16
+type t_id32 uint32
+type t_user_id uint64
+
+func Test_Defined_Key_Type_Uint8(t *testing.T) {
+	run_key_width_suite[t_id8](t, 255)
+}
+
+func Test_Defined_Key_Type_Uint16(t *testing.T) {
+	run_key_width_suite[t_id16](t, 65535)
+}
+
+func Test_Defined_Key_Type_Uint32(t *testing.T) {
+	run_key_width_suite[t_id32](t, 4294967295)
+}
+
+func Test_Defined_Key_Type_Uint64(t *testing.T) {
+	run_key_width_suite[t_user_id](t, 18446744073709551615)
+}
+
+func Test_Defined_Key_Type_Without_Conversions(t *testing.T) {
+	users := dam.New[t_user_id, string](
+		1024,
+		dam.With_Hash_Func[t_user_id, string](func(id t_user_id) uint64 { return uint64(id >> 8) }),
+	)
+
+	var id t_user_id = 42
+	users.Set(id, "alice")
+	users.Set(id+1, "bob")
+
+	if name, ok := users.Get(id); !ok || name != "alice" {
+		t.Fatalf("Get(%d) = (%q, %v).", id, name, ok)
+	}
+	for k, v := range users.All() {
+		var _ t_user_id = k
+		if v == "" {
+			t.Fatalf("Empty value for key %d.", k)
+		}
+	}
+	if !users.Delete(id + 1) {
+		t.Fatalf("Delete(%d) did not find the key.", id+1)
+	}
+}
@@ -6,10 +6,11 @@
 package dam_tests
 
 import (
+	"iter"
 	"math/rand"
 	"testing"
 
-	"github.com/nacioboi/go_dam_map/dam"
+	"github.com/nacioboi/go_dam/dam/dam"
 )
 
 func Benchmark_Linear_Builtin_Map_Set(b *testing.B) {
@@ -140,6 +141,239 @@ func Benchmark_Random_DAM_Get(b *testing.B) {
 	}
 }
 
+func Benchmark_Linear_Builtin_Map_Delete(b *testing.B) {
+	builtin_map := make(map[int]int)
+
+	for i := 0; i < b.N; i++ {
+		builtin_map[i+1] = i
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		delete(builtin_map, i+1)
+	}
+}
+
+func Benchmark_Linear_DAM_Delete(b *testing.B) {
+	dam_map := dam.New(
+		uint64(b.N), dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__NORMAL),
+	)
+
+	for i := uint64(0); i < uint64(b.N); i++ {
+		dam_map.Set(i+1, i)
+	}
+
+	b.ResetTimer()
+	for i := uint64(0); i < uint64(b.N); i++ {
+		if !dam_map.Delete(i + 1) {
+			panic("Key not found.")
+		}
+	}
+}
+
+func Benchmark_Random_Builtin_Map_Delete(b *testing.B) {
+	builtin_map := make(map[int]int)
+
+	for i := 0; i < b.N; i++ {
+		builtin_map[i+1] = i
+	}
+
+	keys := generate_random_keys(b.N)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		delete(builtin_map, keys[i])
+	}
+}
+
+func bench_random_DAM_delete(b *testing.B, strategy dam.T_Delete_Strategy) {
+	dam_map := dam.New(
+		uint64(b.N),
+		dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__NORMAL),
+		dam.With_Delete_Strategy[uint64, uint64](strategy),
+	)
+
+	for i := 0; i < b.N; i++ {
+		dam_map.Set(uint64(i+1), uint64(i))
+	}
+
+	keys := generate_random_keys(b.N)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !dam_map.Delete(uint64(keys[i])) {
+			panic("Key not found.")
+		}
+	}
+}
+
+func Benchmark_Random_DAM_Delete_Swap_Remove(b *testing.B) {
+	bench_random_DAM_delete(b, dam.DELETE_STRATEGY__SWAP_REMOVE)
+}
+
+func Benchmark_Random_DAM_Delete_Ordered_Shift(b *testing.B) {
+	bench_random_DAM_delete(b, dam.DELETE_STRATEGY__ORDERED_SHIFT)
+}
+
+// The methods every map under test shares, so that one workload can check them all against a builtin map.
+type i_test_map[KT comparable] interface {
+	Len() int
+	Get(key KT) (uint64, bool)
+	Set(key KT, value uint64)
+	Delete(key KT) bool
+}
+
+// A random workload for `run_against_builtin_map`.
+type t_workload[KT comparable] struct {
+	num_ops    int
+	random_key func(rng *rand.Rand) KT
+	// One operation in `delete_ratio` deletes its key, the others set it, unless `no_sets` is true.
+	delete_ratio int
+	no_sets      bool
+}
+
+// Apply `w` to both `m` and `builtin_map`, checking every result along the way and the whole map at the end.
+//
+// Every operation first gets its key, so lookups of missing keys are covered too.
+func run_against_builtin_map[KT comparable](t *testing.T, m i_test_map[KT], builtin_map map[KT]uint64, w t_workload[KT]) {
+	t.Helper()
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < w.num_ops; i++ {
+		key := w.random_key(rng)
+
+		expected_value, expected_ok := builtin_map[key]
+		if v, ok := m.Get(key); ok != expected_ok || v != expected_value {
+			t.Fatalf("Get(%v) = (%d, %v), expected (%d, %v).", key, v, ok, expected_value, expected_ok)
+		}
+
+		if rng.Intn(w.delete_ratio) == 0 {
+			delete(builtin_map, key)
+			if got := m.Delete(key); got != expected_ok {
+				t.Fatalf("Delete(%v) = %v, expected %v.", key, got, expected_ok)
+			}
+		} else if !w.no_sets {
+			builtin_map[key] = uint64(i)
+			m.Set(key, uint64(i))
+		}
+
+		if m.Len() != len(builtin_map) {
+			t.Fatalf("Len() = %d after %d operations, expected %d.", m.Len(), i+1, len(builtin_map))
+		}
+	}
+
+	check_against_builtin_map(t, m, builtin_map)
+}
+
+// Check that `m` holds exactly the entries of `builtin_map`, through `Get` and also through `All` when `m` has one.
+func check_against_builtin_map[KT comparable](
+	t *testing.T,
+	m interface {
+		Len() int
+		Get(key KT) (uint64, bool)
+	},
+	builtin_map map[KT]uint64,
+) {
+	t.Helper()
+
+	for k, v := range builtin_map {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("Get(%v) = (%d, %v), expected (%d, true).", k, got, ok, v)
+		}
+	}
+
+	if iterable, ok := m.(interface{ All() iter.Seq2[KT, uint64] }); ok {
+		check_all_against_builtin_map(t, m.Len(), iterable.All(), builtin_map)
+	} else if m.Len() != len(builtin_map) {
+		t.Fatalf("Len() = %d, expected %d.", m.Len(), len(builtin_map))
+	}
+}
+
+// Check that `all` yields every entry of `builtin_map` exactly once, and that `num_entries` agrees.
+func check_all_against_builtin_map[KT, VT comparable](t *testing.T, num_entries int, all iter.Seq2[KT, VT], builtin_map map[KT]VT) {
+	t.Helper()
+
+	if num_entries != len(builtin_map) {
+		t.Fatalf("Len() = %d, expected %d.", num_entries, len(builtin_map))
+	}
+
+	seen := make(map[KT]struct{}, len(builtin_map))
+	for k, v := range all {
+		if expected, ok := builtin_map[k]; !ok || v != expected {
+			t.Fatalf("All yielded (%v, %v), expected (%v, %v).", k, v, k, expected)
+		}
+		if _, ok := seen[k]; ok {
+			t.Fatalf("All yielded %v twice.", k)
+		}
+		seen[k] = struct{}{}
+	}
+	if len(seen) != len(builtin_map) {
+		t.Fatalf("All yielded %d entries, expected %d.", len(seen), len(builtin_map))
+	}
+}
+
+func test_delete_against_builtin_map(t *testing.T, strategy dam.T_Delete_Strategy) {
+	const n = 1024 * 16
+
+	dam_map := dam.New(
+		uint64(n),
+		dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__SAVE_MEMORY),
+		dam.With_Delete_Strategy[uint64, uint64](strategy),
+	)
+	run_against_builtin_map(t, dam_map, make(map[uint64]uint64), t_workload[uint64]{
+		num_ops:      n * 8,
+		random_key:   func(rng *rand.Rand) uint64 { return uint64(rng.Intn(n*2)) + 1 },
+		delete_ratio: 3,
+	})
+}
+
+func Test_Delete_Swap_Remove_Against_Builtin_Map(t *testing.T) {
+	test_delete_against_builtin_map(t, dam.DELETE_STRATEGY__SWAP_REMOVE)
+}
+
+func Test_Delete_Ordered_Shift_Against_Builtin_Map(t *testing.T) {
+	test_delete_against_builtin_map(t, dam.DELETE_STRATEGY__ORDERED_SHIFT)
+}
+
+func Test_Delete_Missing_Key(t *testing.T) {
+	dam_map := dam.New[uint64, uint64](64)
+	dam_map.Set(1, 1)
+
+	if dam_map.Delete(2) {
+		t.Fatalf("Delete of a missing key reported success.")
+	}
+	if !dam_map.Delete(1) {
+		t.Fatalf("Delete of a present key reported failure.")
+	}
+	if dam_map.Delete(1) {
+		t.Fatalf("Second delete of the same key reported success.")
+	}
+	if _, ok := dam_map.Get(1); ok {
+		t.Fatalf("Deleted key is still present.")
+	}
+}
+
+func Test_Delete_Reuses_Bucket_Capacity(t *testing.T) {
+	const n = 1024
+	dam_map := dam.New[uint64, uint64](n)
+
+	for i := uint64(0); i < n; i++ {
+		dam_map.Set(i+1, i)
+	}
+
+	allocs := testing.AllocsPerRun(16, func() {
+		for i := uint64(0); i < n; i++ {
+			dam_map.Delete(i + 1)
+		}
+		for i := uint64(0); i < n; i++ {
+			dam_map.Set(i+1, i)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("Expected re-inserting deleted keys to reuse bucket capacity, got %f allocations.", allocs)
+	}
+}
+
 func generate_random_keys(n int) []int {
 	keys := make([]int, n)
 	for i := 0; i < n; i++ {
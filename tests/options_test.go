@@ -0,0 +1,109 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+type t_opt = dam.T_Option[uint64, uint64]
+
+func Test_New_With_Error_Accepts_Valid_Options(t *testing.T) {
+	dam_map, err := dam.New_With_Error(uint64(1024),
+		dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__FAST),
+		dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__MURMUR3),
+		dam.With_Delete_Strategy[uint64, uint64](dam.DELETE_STRATEGY__ORDERED_SHIFT),
+		dam.With_Incremental_Resize[uint64, uint64](4),
+		dam.With_Range_Reduction[uint64, uint64](true),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	dam_map.Set(1, 2)
+	if v, ok := dam_map.Get(1); !ok || v != 2 {
+		t.Fatalf("Get(1) = (%d, %v), expected (2, true).", v, ok)
+	}
+}
+
+func Test_New_With_Error_Rejects_Invalid_Options(t *testing.T) {
+	cases := map[string]struct {
+		option t_opt
+		want   dam.T_Option_Type
+	}{
+		"Profile":        {dam.With_Performance_Profile[uint64, uint64](dam.T_Performance_Profile(42)), dam.OPTION_TYPE__WITH_PERFORMANCE_PROFILE},
+		"Delete":         {dam.With_Delete_Strategy[uint64, uint64](dam.T_Delete_Strategy(42)), dam.OPTION_TYPE__WITH_DELETE_STRATEGY},
+		"Mixer":          {dam.With_Hash_Mixer[uint64, uint64](dam.T_Hash_Mixer(42)), dam.OPTION_TYPE__WITH_HASH_MIXER},
+		"Unseeded_Mixer": {dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__SEEDED), dam.OPTION_TYPE__WITH_HASH_MIXER},
+		"Nil_Hash_Func":  {dam.With_Hash_Func[uint64, uint64](nil), dam.OPTION_TYPE__WITH_HASH_FUNC},
+		"Zero_Option":    {t_opt{}, dam.OPTION_TYPE__WITH_HASH_FUNC},
+	}
+
+	for name, c := range cases {
+		dam_map, err := dam.New_With_Error(uint64(1024), c.option)
+		if dam_map != nil {
+			t.Fatalf("%s: expected no map on error.", name)
+		}
+
+		var invalid *dam.T_Invalid_Option_Error
+		if !errors.As(err, &invalid) {
+			t.Fatalf("%s: expected a `*T_Invalid_Option_Error`, got %v.", name, err)
+		}
+		if invalid.Option != c.want {
+			t.Fatalf("%s: error names option %v, expected %v.", name, invalid.Option, c.want)
+		}
+	}
+}
+
+func Test_New_With_Error_Rejects_Conflicting_Options(t *testing.T) {
+	cases := map[string]struct {
+		options []t_opt
+		want    [2]dam.T_Option_Type
+	}{
+		"Hash_Func_And_Mixer": {
+			[]t_opt{
+				dam.With_Hash_Func[uint64, uint64](func(k uint64) uint64 { return k }),
+				dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__FIBONACCI),
+			},
+			[2]dam.T_Option_Type{dam.OPTION_TYPE__WITH_HASH_MIXER, dam.OPTION_TYPE__WITH_HASH_FUNC},
+		},
+		"Incremental_Without_Growth": {
+			[]t_opt{
+				dam.With_Incremental_Resize[uint64, uint64](4),
+				dam.With_Auto_Growth[uint64, uint64](false),
+			},
+			[2]dam.T_Option_Type{dam.OPTION_TYPE__WITH_INCREMENTAL_RESIZE, dam.OPTION_TYPE__WITH_AUTO_GROWTH},
+		},
+		"Twice": {
+			[]t_opt{
+				dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__FAST),
+				dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__NORMAL),
+			},
+			[2]dam.T_Option_Type{dam.OPTION_TYPE__WITH_PERFORMANCE_PROFILE, dam.OPTION_TYPE__WITH_PERFORMANCE_PROFILE},
+		},
+	}
+
+	for name, c := range cases {
+		_, err := dam.New_With_Error(uint64(1024), c.options...)
+
+		var conflict *dam.T_Conflicting_Options_Error
+		if !errors.As(err, &conflict) {
+			t.Fatalf("%s: expected a `*T_Conflicting_Options_Error`, got %v.", name, err)
+		}
+		if got := [2]dam.T_Option_Type{conflict.Option, conflict.Conflicts_With}; got != c.want {
+			t.Fatalf("%s: error names options %v, expected %v.", name, got, c.want)
+		}
+	}
+}
+
+func Test_New_Panics_On_Invalid_Options(t *testing.T) {
+	expect_panic(t, "an invalid profile", func() {
+		dam.New(uint64(1024), dam.With_Performance_Profile[uint64, uint64](dam.T_Performance_Profile(42)))
+	})
+}
@@ -0,0 +1,116 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Range_Reduction_Uses_Exact_Bucket_Count(t *testing.T) {
+	const expected = 600_000
+
+	masked := dam.New[uint64, uint64](expected)
+	reduced := dam.New(uint64(expected), dam.With_Range_Reduction[uint64, uint64](true))
+
+	if n := masked.Enquire_Number_Of_Buckets(); bits.OnesCount64(n) != 1 || n != (1<<20)/8 {
+		t.Fatalf("Expected the default to round up to %d buckets, got %d.", (1<<20)/8, n)
+	}
+	if n := reduced.Enquire_Number_Of_Buckets(); n != expected/8 {
+		t.Fatalf("Expected %d buckets with range reduction, got %d.", expected/8, n)
+	}
+}
+
+func Test_Range_Reduction_Spreads_Keys(t *testing.T) {
+	const n = 100_000
+
+	patterns := map[string]func(i uint64) uint64{
+		"Sequential":  func(i uint64) uint64 { return i + 1 },
+		"Stride_1024": func(i uint64) uint64 { return (i + 1) * 1024 },
+	}
+	for name, key := range patterns {
+		dam_map := dam.New(uint64(n), dam.With_Range_Reduction[uint64, uint64](true))
+		for i := uint64(0); i < n; i++ {
+			dam_map.Set(key(i), i)
+		}
+
+		s := dam_map.Stats()
+		if float64(s.Max_Entries_Per_Bucket) > 4*s.Mean_Entries_Per_Bucket {
+			t.Errorf("%s: max bucket length %d for a mean of %f.", name, s.Max_Entries_Per_Bucket, s.Mean_Entries_Per_Bucket)
+		}
+		for i := uint64(0); i < n; i++ {
+			if v, ok := dam_map.Get(key(i)); !ok || v != i {
+				t.Fatalf("%s: Get(%d) = (%d, %v).", name, key(i), v, ok)
+			}
+		}
+	}
+}
+
+func Test_Range_Reduction_Against_Builtin_Map(t *testing.T) {
+	const n = 1024 * 32
+
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Stop_The_World": {
+			dam.With_Range_Reduction[uint64, uint64](true),
+		},
+		"Incremental_With_Hash_Func": {
+			dam.With_Range_Reduction[uint64, uint64](true),
+			dam.With_Incremental_Resize[uint64, uint64](1),
+			dam.With_Hash_Func[uint64, uint64](strip_shard),
+		},
+	}
+
+	for name, options := range option_sets {
+		t.Run(name, func(t *testing.T) {
+			dam_map := dam.New(uint64(1000), options...)
+			run_against_builtin_map(t, dam_map, make(map[uint64]uint64), t_workload[uint64]{
+				num_ops:      n,
+				random_key:   func(rng *rand.Rand) uint64 { return shard_id(uint64(rng.Intn(n))) },
+				delete_ratio: 4,
+			})
+
+			if n := dam_map.Enquire_Number_Of_Buckets(); bits.OnesCount64(n) == 1 {
+				t.Fatalf("Expected growth to keep a non power of two bucket count, got %d.", n)
+			}
+		})
+	}
+}
+
+func bench_random_DAM_get_range_reduction(b *testing.B, enabled bool) {
+	dam_map := dam.New(
+		uint64(b.N),
+		dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__NORMAL),
+		dam.With_Range_Reduction[uint64, uint64](enabled),
+	)
+
+	for i := 0; i < b.N; i++ {
+		dam_map.Set(uint64(i+1), uint64(i))
+	}
+
+	keys := generate_random_keys(b.N)
+
+	var t uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, ok := dam_map.Get(uint64(keys[i]))
+		if ok {
+			t += x
+		} else {
+			panic("Key not found.")
+		}
+	}
+}
+
+func Benchmark_Random_DAM_Get_Power_Of_Two_Mask(b *testing.B) {
+	bench_random_DAM_get_range_reduction(b, false)
+}
+
+func Benchmark_Random_DAM_Get_Range_Reduction(b *testing.B) {
+	bench_random_DAM_get_range_reduction(b, true)
+}
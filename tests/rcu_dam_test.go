@@ -0,0 +1,105 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_RCU_DAM_Against_Builtin_Map(t *testing.T) {
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Default": nil,
+		"Range":   {dam.With_Range_Reduction[uint64, uint64](true)},
+		"Ordered": {dam.With_Delete_Strategy[uint64, uint64](dam.DELETE_STRATEGY__ORDERED_SHIFT)},
+	}
+
+	for name, options := range option_sets {
+		t.Run(name, func(t *testing.T) {
+			run_against_builtin_map(t, dam.New_RCU_DAM(uint64(8), options...), make(map[uint64]uint64), t_workload[uint64]{
+				num_ops:      1 << 15,
+				random_key:   func(rng *rand.Rand) uint64 { return uint64(rng.Intn(1 << 13)) },
+				delete_ratio: 4,
+			})
+		})
+	}
+}
+
+// Every read must return a version between the last write that completed before it started
+// and the last write that started before it ended, including while the table grows.
+func Test_RCU_DAM_Reads_Are_Linearizable(t *testing.T) {
+	const num_writers = 4
+	const num_readers = 4
+	const keys_per_writer = 256
+	const num_keys = num_writers * keys_per_writer
+
+	// Start tiny so that the table is replaced while readers hold the old one...
+	r := dam.New_RCU_DAM[uint64, uint64](8)
+	var started, completed [num_keys]atomic.Uint64
+
+	var stop atomic.Bool
+	var writers, readers sync.WaitGroup
+	for w := 0; w < num_writers; w++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			rng := rand.New(rand.NewSource(int64(w)))
+			for version := uint64(1); !stop.Load(); version++ {
+				key := uint64(w*keys_per_writer + rng.Intn(keys_per_writer))
+				started[key].Store(version)
+				r.Set(key, version)
+				completed[key].Store(version)
+			}
+		}()
+	}
+
+	for g := 0; g < num_readers; g++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			rng := rand.New(rand.NewSource(int64(g) + 100))
+			var last_seen [num_keys]uint64
+			for i := 0; i < 1<<16; i++ {
+				key := uint64(rng.Intn(num_keys))
+
+				lo := completed[key].Load()
+				got, ok := r.Get(key)
+				hi := started[key].Load()
+
+				if !ok {
+					got = 0
+				}
+				if got < lo || got > hi {
+					t.Errorf("Get(%d) = %d, expected a version between %d and %d.", key, got, lo, hi)
+					return
+				}
+				if got < last_seen[key] {
+					t.Errorf("Get(%d) went back from version %d to %d.", key, last_seen[key], got)
+					return
+				}
+				last_seen[key] = got
+			}
+		}()
+	}
+
+	readers.Wait()
+	stop.Store(true)
+	writers.Wait()
+
+	if r.Enquire_Number_Of_Buckets() == 1 {
+		t.Fatalf("Expected the table to grow.")
+	}
+}
+
+func Test_RCU_DAM_Rejects_Incremental_Resize(t *testing.T) {
+	expect_panic(t, "incremental resize", func() {
+		dam.New_RCU_DAM(uint64(64), dam.With_Incremental_Resize[uint64, uint64](4))
+	})
+}
@@ -0,0 +1,222 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Get_Or_Set_And_Swap(t *testing.T) {
+	dam_map := dam.New[uint64, uint64](64)
+
+	if v, loaded := dam_map.Get_Or_Set(1, 10); loaded || v != 10 {
+		t.Fatalf("Get_Or_Set on a missing key = (%d, %v), expected (10, false).", v, loaded)
+	}
+	if v, loaded := dam_map.Get_Or_Set(1, 20); !loaded || v != 10 {
+		t.Fatalf("Get_Or_Set on a present key = (%d, %v), expected (10, true).", v, loaded)
+	}
+
+	if v, loaded := dam_map.Swap(1, 30); !loaded || v != 10 {
+		t.Fatalf("Swap on a present key = (%d, %v), expected (10, true).", v, loaded)
+	}
+	if v, loaded := dam_map.Swap(2, 40); loaded || v != 0 {
+		t.Fatalf("Swap on a missing key = (%d, %v), expected (0, false).", v, loaded)
+	}
+
+	if v, _ := dam_map.Get(1); v != 30 {
+		t.Fatalf("Get(1) = %d after Swap, expected 30.", v)
+	}
+	if dam_map.Len() != 2 {
+		t.Fatalf("Len() = %d, expected 2.", dam_map.Len())
+	}
+}
+
+func Test_Compute(t *testing.T) {
+	dam_map := dam.New[uint64, uint64](64)
+
+	inc := func(old uint64, exists bool) (uint64, bool) {
+		return old + 1, true
+	}
+	for i := 0; i < 3; i++ {
+		dam_map.Compute(7, inc)
+	}
+	if v, ok := dam_map.Get(7); !ok || v != 3 {
+		t.Fatalf("Get(7) = (%d, %v) after 3 increments, expected (3, true).", v, ok)
+	}
+
+	// Returning false deletes the key, or does not insert it...
+	if _, ok := dam_map.Compute(7, func(uint64, bool) (uint64, bool) { return 0, false }); ok {
+		t.Fatalf("Expected Compute to report the key as deleted.")
+	}
+	if _, ok := dam_map.Get(7); ok || dam_map.Len() != 0 {
+		t.Fatalf("Expected key 7 to be deleted.")
+	}
+	dam_map.Compute(8, func(old uint64, exists bool) (uint64, bool) {
+		if exists {
+			t.Fatalf("Key 8 should not exist.")
+		}
+		return 0, false
+	})
+	if dam_map.Len() != 0 {
+		t.Fatalf("Expected Compute returning false not to insert.")
+	}
+}
+
+func Test_Compare_And_Swap(t *testing.T) {
+	dam_map := dam.New[uint64, uint64](64)
+
+	if dam.Compare_And_Swap(dam_map, 1, 0, 5) {
+		t.Fatalf("Compare_And_Swap succeeded on a missing key.")
+	}
+	dam_map.Set(1, 5)
+	if dam.Compare_And_Swap(dam_map, 1, 4, 6) {
+		t.Fatalf("Compare_And_Swap succeeded with the wrong old value.")
+	}
+	if !dam.Compare_And_Swap(dam_map, 1, 5, 6) {
+		t.Fatalf("Compare_And_Swap failed with the right old value.")
+	}
+	if v, _ := dam_map.Get(1); v != 6 {
+		t.Fatalf("Get(1) = %d, expected 6.", v)
+	}
+}
+
+func Test_Compute_Against_Builtin_Map_While_Growing(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	builtin_map := make(map[uint64]uint64)
+	dam_map := dam.New(uint64(8), dam.With_Incremental_Resize[uint64, uint64](1))
+
+	for i := 0; i < 1<<15; i++ {
+		key := uint64(rng.Intn(1 << 12))
+		increment := rng.Intn(3) != 0
+
+		// Counters that reach 0 are dropped...
+		if increment {
+			builtin_map[key]++
+		} else if builtin_map[key] > 1 {
+			builtin_map[key]--
+		} else {
+			delete(builtin_map, key)
+		}
+		dam_map.Compute(key, func(old uint64, exists bool) (uint64, bool) {
+			if increment {
+				return old + 1, true
+			}
+			return old - 1, old > 1
+		})
+	}
+
+	if dam_map.Len() != len(builtin_map) {
+		t.Fatalf("Len() = %d, expected %d.", dam_map.Len(), len(builtin_map))
+	}
+	for key, v := range builtin_map {
+		if got, ok := dam_map.Get(key); !ok || got != v {
+			t.Fatalf("Get(%d) = (%d, %v), expected (%d, true).", key, got, ok, v)
+		}
+	}
+}
+
+func Test_Concurrent_DAM_Read_Modify_Write_Is_Atomic(t *testing.T) {
+	const num_goroutines = 8
+	const num_increments = 1 << 12
+	const num_keys = 64
+
+	// Start tiny so that increments race with growth as well...
+	c := dam.New_Concurrent_DAM[uint64, uint64](4, 4)
+
+	var wg sync.WaitGroup
+	for g := 0; g < num_goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < num_increments; i++ {
+				key := uint64(i % num_keys)
+				switch i % 3 {
+				case 0:
+					c.Compute(key, func(old uint64, exists bool) (uint64, bool) {
+						return old + 1, true
+					})
+				case 1:
+					for {
+						old, _ := c.Get_Or_Set(key, 0)
+						if dam.Concurrent_Compare_And_Swap(c, key, old, old+1) {
+							break
+						}
+					}
+				default:
+					c.Compute(key+num_keys, func(old uint64, exists bool) (uint64, bool) {
+						return old + 1, true
+					})
+					c.Swap(key+2*num_keys, uint64(g))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var total uint64
+	for key := uint64(0); key < 2*num_keys; key++ {
+		v, _ := c.Get(key)
+		total += v
+	}
+	if total != num_goroutines*num_increments {
+		t.Fatalf("Counted %d increments, expected %d.", total, num_goroutines*num_increments)
+	}
+}
+
+func Test_Concurrent_DAM_Compute_Unlocks_When_F_Panics(t *testing.T) {
+	c := dam.New_Concurrent_DAM[uint64, uint64](64, 1)
+	c.Set(1, 10)
+
+	for _, key := range []uint64{1, 2} {
+		expect_panic(t, "a panicking Compute", func() {
+			c.Compute(key, func(old uint64, exists bool) (uint64, bool) {
+				panic("f failed.")
+			})
+		})
+	}
+
+	// The only stripe must be free again, and the map unchanged...
+	c.Set(2, 20)
+	if v, ok := c.Get(1); !ok || v != 10 {
+		t.Fatalf("Get(1) = (%d, %v), expected (10, true).", v, ok)
+	}
+	if v, ok := c.Get(2); !ok || v != 20 || c.Len() != 2 {
+		t.Fatalf("Get(2) = (%d, %v) with Len() = %d, expected (20, true) with 2.", v, ok, c.Len())
+	}
+}
+
+func Benchmark_Random_DAM_Increment_Get_Then_Set(b *testing.B) {
+	const n = 1 << 16
+
+	dam_map := dam.New[uint64, uint64](n)
+	rng := rand.New(rand.NewSource(1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := uint64(rng.Intn(n))
+		v, _ := dam_map.Get(key)
+		dam_map.Set(key, v+1)
+	}
+}
+
+func Benchmark_Random_DAM_Increment_Compute(b *testing.B) {
+	const n = 1 << 16
+
+	dam_map := dam.New[uint64, uint64](n)
+	rng := rand.New(rand.NewSource(1))
+	inc := func(old uint64, exists bool) (uint64, bool) {
+		return old + 1, true
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dam_map.Compute(uint64(rng.Intn(n)), inc)
+	}
+}
@@ -0,0 +1,100 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_SFDA_Resizable_Map_Grows_And_Shrinks(t *testing.T) {
+	const n = 1024 * 64
+
+	m := dam.New_SFDA_Resizable_Map[uint64, uint64](64)
+	min_num_buckets := m.Enquire_Number_Of_Buckets()
+
+	for i := uint64(0); i < n; i++ {
+		m.Set(i+1, i)
+	}
+	if m.Len() != n {
+		t.Fatalf("Len() = %d, expected %d.", m.Len(), n)
+	}
+	grown_num_buckets := m.Enquire_Number_Of_Buckets()
+	if grown_num_buckets <= min_num_buckets {
+		t.Fatalf("Bucket count did not grow from %d.", min_num_buckets)
+	}
+
+	for i := uint64(0); i < n-16; i++ {
+		if !m.Delete(i + 1) {
+			t.Fatalf("Delete(%d) did not find the key.", i+1)
+		}
+	}
+	if m.Enquire_Number_Of_Buckets() != min_num_buckets {
+		t.Fatalf("Expected to shrink back to %d buckets, got %d.", min_num_buckets, m.Enquire_Number_Of_Buckets())
+	}
+
+	for i := uint64(n - 16); i < n; i++ {
+		if v, ok := m.Get(i + 1); !ok || v != i {
+			t.Fatalf("Get(%d) = (%d, %v) after shrinking.", i+1, v, ok)
+		}
+	}
+}
+
+func Test_SFDA_Resizable_Map_Against_Builtin_Map(t *testing.T) {
+	const n = 1024 * 32
+
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Default":     nil,
+		"Incremental": {dam.With_Incremental_Resize[uint64, uint64](2)},
+		"Range":       {dam.With_Range_Reduction[uint64, uint64](true)},
+	}
+
+	for name, options := range option_sets {
+		t.Run(name, func(t *testing.T) {
+			m := dam.New_SFDA_Resizable_Map(uint64(100), options...)
+			builtin_map := make(map[uint64]uint64)
+			random_key := func(rng *rand.Rand) uint64 { return uint64(rng.Intn(n) + 1) }
+
+			// Fill up, then drain, so both growing and shrinking happen...
+			run_against_builtin_map(t, m, builtin_map, t_workload[uint64]{num_ops: n, random_key: random_key, delete_ratio: 8})
+			run_against_builtin_map(t, m, builtin_map, t_workload[uint64]{num_ops: n, random_key: random_key, delete_ratio: 2, no_sets: true})
+		})
+	}
+}
+
+func Test_SFDA_Resizable_Map_Is_Deterministic(t *testing.T) {
+	run := func() []uint64 {
+		rng := rand.New(rand.NewSource(7))
+		m := dam.New_SFDA_Resizable_Map[uint64, uint64](64)
+
+		var history []uint64
+		for i := 0; i < 1024*16; i++ {
+			key := uint64(rng.Intn(1024*4) + 1)
+			if i > 1024*8 {
+				m.Delete(key)
+			} else {
+				m.Set(key, key)
+			}
+			history = append(history, m.Enquire_Number_Of_Buckets())
+		}
+		return history
+	}
+
+	a, b := run(), run()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Bucket count diverged at step %d: %d vs %d.", i, a[i], b[i])
+		}
+	}
+}
+
+func Test_SFDA_Resizable_Map_Rejects_Disabled_Growth(t *testing.T) {
+	expect_panic(t, "disabling growth", func() {
+		dam.New_SFDA_Resizable_Map(uint64(64), dam.With_Auto_Growth[uint64, uint64](false))
+	})
+}
@@ -0,0 +1,82 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func run_signed_suite[KT dam.I_Signed_Integer](t *testing.T, min_key KT, max_key KT) {
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Default":     nil,
+		"Mixer":       {dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__MURMUR3)},
+		"Range":       {dam.With_Range_Reduction[uint64, uint64](true)},
+		"Incremental": {dam.With_Incremental_Resize[uint64, uint64](1)},
+	}
+
+	for name, options := range option_sets {
+		t.Run(name, func(t *testing.T) {
+			m := dam.New_Signed_DAM[KT](16, options...)
+
+			// The extremes and their neighbours...
+			edges := []KT{min_key, min_key + 1, -1, 0, 1, max_key - 1, max_key}
+			for i, k := range edges {
+				m.Set(k, uint64(i))
+			}
+			for i, k := range edges {
+				if v, ok := m.Get(k); !ok || v != uint64(i) {
+					t.Fatalf("Get(%d) = (%d, %v), expected (%d, true).", k, v, ok, i)
+				}
+			}
+			m.Clear()
+
+			// A mixed-sign workload around 0...
+			span := min(int64(max_key), 1<<14)
+			run_against_builtin_map(t, m, make(map[KT]uint64), t_workload[KT]{
+				num_ops:      int(span) * 8,
+				random_key:   func(rng *rand.Rand) KT { return KT(rng.Int63n(span*2) - span) },
+				delete_ratio: 4,
+			})
+		})
+	}
+}
+
+func Test_Signed_DAM_Int8(t *testing.T) {
+	run_signed_suite[int8](t, math.MinInt8, math.MaxInt8)
+}
+
+func Test_Signed_DAM_Int16(t *testing.T) {
+	run_signed_suite[int16](t, math.MinInt16, math.MaxInt16)
+}
+
+func Test_Signed_DAM_Int32(t *testing.T) {
+	run_signed_suite[int32](t, math.MinInt32, math.MaxInt32)
+}
+
+func Test_Signed_DAM_Int64(t *testing.T) {
+	run_signed_suite[int64](t, math.MinInt64, math.MaxInt64)
+}
+
+func Test_Signed_DAM_Spreads_Small_Mixed_Sign_Keys(t *testing.T) {
+	const span = 1024 * 8
+
+	m := dam.New_Signed_DAM[int64, uint64](span * 2)
+	for k := int64(-span); k < span; k++ {
+		m.Set(k, uint64(k+span))
+	}
+
+	s := m.Stats()
+	if s.Min_Entries_Per_Bucket != s.Max_Entries_Per_Bucket {
+		t.Fatalf(
+			"Expected keys in [-%d, %d) to fill every bucket evenly, got min %d and max %d.",
+			span, span, s.Min_Entries_Per_Bucket, s.Max_Entries_Per_Bucket,
+		)
+	}
+}
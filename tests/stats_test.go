@@ -0,0 +1,120 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Len_Tracks_Set_And_Delete(t *testing.T) {
+	const n = 1024
+	dam_map := dam.New[uint64, uint64](n)
+
+	for i := uint64(0); i < n; i++ {
+		dam_map.Set(i+1, i)
+	}
+	if dam_map.Len() != n {
+		t.Fatalf("Len() = %d after inserting, expected %d.", dam_map.Len(), n)
+	}
+
+	// Overwriting must not change the length...
+	for i := uint64(0); i < n; i++ {
+		dam_map.Set(i+1, i*2)
+	}
+	if dam_map.Len() != n {
+		t.Fatalf("Len() = %d after overwriting, expected %d.", dam_map.Len(), n)
+	}
+
+	for i := uint64(0); i < n/2; i++ {
+		dam_map.Delete(i + 1)
+	}
+	dam_map.Delete(n + 1)
+	if dam_map.Len() != n/2 {
+		t.Fatalf("Len() = %d after deleting, expected %d.", dam_map.Len(), n/2)
+	}
+}
+
+func Test_Clear_Keeps_Capacity(t *testing.T) {
+	const n = 1024
+	dam_map := dam.New[uint64, uint64](n)
+
+	for i := uint64(0); i < n; i++ {
+		dam_map.Set(i+1, i)
+	}
+	capacity := dam_map.Stats().Capacity_Bytes
+
+	dam_map.Clear()
+	if dam_map.Len() != 0 {
+		t.Fatalf("Len() = %d after Clear, expected 0.", dam_map.Len())
+	}
+	if _, ok := dam_map.Get(1); ok {
+		t.Fatalf("Key still present after Clear.")
+	}
+
+	s := dam_map.Stats()
+	if s.Capacity_Bytes != capacity {
+		t.Fatalf("Capacity changed from %d to %d after Clear.", capacity, s.Capacity_Bytes)
+	}
+	if s.Used_Bytes != 0 {
+		t.Fatalf("Used_Bytes = %d after Clear, expected 0.", s.Used_Bytes)
+	}
+
+	allocs := testing.AllocsPerRun(4, func() {
+		for i := uint64(0); i < n; i++ {
+			dam_map.Set(i+1, i)
+		}
+		dam_map.Clear()
+	})
+	if allocs != 0 {
+		t.Fatalf("Expected refilling a cleared map not to allocate, got %f allocations.", allocs)
+	}
+}
+
+func Test_Stats(t *testing.T) {
+	const n = 1024
+	dam_map := dam.New(
+		uint64(n), dam.With_Performance_Profile[uint64, uint64](dam.PERFORMANCE_PROFILE__NORMAL),
+	)
+
+	for i := uint64(0); i < n; i++ {
+		dam_map.Set(i+1, i)
+	}
+
+	s := dam_map.Stats()
+	if s.Num_Buckets != dam_map.Enquire_Number_Of_Buckets() {
+		t.Fatalf("Num_Buckets = %d, expected %d.", s.Num_Buckets, dam_map.Enquire_Number_Of_Buckets())
+	}
+	if s.Num_Entries != n {
+		t.Fatalf("Num_Entries = %d, expected %d.", s.Num_Entries, n)
+	}
+
+	// Sequential keys spread perfectly over a power of two number of buckets...
+	per_bucket := uint64(n) / s.Num_Buckets
+	if s.Min_Entries_Per_Bucket != per_bucket || s.Max_Entries_Per_Bucket != per_bucket {
+		t.Fatalf(
+			"Min/Max entries per bucket = %d/%d, expected %d.",
+			s.Min_Entries_Per_Bucket, s.Max_Entries_Per_Bucket, per_bucket,
+		)
+	}
+	if s.Mean_Entries_Per_Bucket != float64(per_bucket) {
+		t.Fatalf("Mean_Entries_Per_Bucket = %f, expected %d.", s.Mean_Entries_Per_Bucket, per_bucket)
+	}
+
+	var num_buckets, num_entries uint64
+	for length, count := range s.Bucket_Length_Histogram {
+		num_buckets += count
+		num_entries += uint64(length) * count
+	}
+	if num_buckets != s.Num_Buckets || num_entries != s.Num_Entries {
+		t.Fatalf("Histogram accounts for %d buckets and %d entries.", num_buckets, num_entries)
+	}
+
+	if s.Used_Bytes == 0 || s.Used_Bytes > s.Capacity_Bytes {
+		t.Fatalf("Used_Bytes = %d, Capacity_Bytes = %d.", s.Used_Bytes, s.Capacity_Bytes)
+	}
+}
@@ -0,0 +1,119 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Tiny_Expected_Num_Inputs(t *testing.T) {
+	profiles := []dam.T_Performance_Profile{
+		dam.PERFORMANCE_PROFILE__FAST,
+		dam.PERFORMANCE_PROFILE__NORMAL,
+		dam.PERFORMANCE_PROFILE__SAVE_MEMORY,
+	}
+
+	for _, profile := range profiles {
+		for _, range_reduction := range []bool{false, true} {
+			for expected := uint64(0); expected <= 8; expected++ {
+				dam_map := dam.New(
+					expected,
+					dam.With_Performance_Profile[uint64, uint64](profile),
+					dam.With_Range_Reduction[uint64, uint64](range_reduction),
+				)
+
+				// Small maps are a single linear array...
+				if n := dam_map.Enquire_Number_Of_Buckets(); n != 1 {
+					t.Fatalf("New(%d) with profile %d made %d buckets, expected 1.", expected, profile, n)
+				}
+
+				// ...and must still grow like any other map.
+				for i := uint64(0); i < 100; i++ {
+					dam_map.Set(i, i)
+				}
+				for i := uint64(0); i < 100; i++ {
+					if v, ok := dam_map.Get(i); !ok || v != i {
+						t.Fatalf("New(%d) with profile %d: Get(%d) = (%d, %v).", expected, profile, i, v, ok)
+					}
+				}
+				if dam_map.Len() != 100 || dam_map.Enquire_Number_Of_Buckets() == 1 {
+					t.Fatalf(
+						"New(%d) with profile %d: Len() = %d with %d buckets.",
+						expected, profile, dam_map.Len(), dam_map.Enquire_Number_Of_Buckets(),
+					)
+				}
+			}
+		}
+	}
+}
+
+func Test_Tiny_Map_Stays_Small_Within_A_Cache_Line_Or_Two(t *testing.T) {
+	dam_map := dam.New[uint64, uint64](4)
+
+	// 8 entries of 16 bytes fill two cache lines...
+	for i := uint64(0); i < 8; i++ {
+		dam_map.Set(i, i)
+	}
+	if n := dam_map.Enquire_Number_Of_Buckets(); n != 1 {
+		t.Fatalf("Expected 8 entries to stay in a single bucket, got %d buckets.", n)
+	}
+
+	dam_map.Set(8, 8)
+	if n := dam_map.Enquire_Number_Of_Buckets(); n == 1 {
+		t.Fatalf("Expected the map to leave the single bucket after 9 entries.")
+	}
+}
+
+func Test_Tiny_SFDA_Resizable_Map(t *testing.T) {
+	for expected := uint64(0); expected <= 2; expected++ {
+		m := dam.New_SFDA_Resizable_Map[uint64, uint64](expected)
+		for i := uint64(0); i < 64; i++ {
+			m.Set(i, i)
+		}
+		for i := uint64(0); i < 64; i++ {
+			m.Delete(i)
+		}
+		if m.Len() != 0 || m.Enquire_Number_Of_Buckets() != 1 {
+			t.Fatalf("New_SFDA_Resizable_Map(%d): Len() = %d with %d buckets after draining.", expected, m.Len(), m.Enquire_Number_Of_Buckets())
+		}
+	}
+}
+
+// Keeps the maps below on the heap, like per-session maps that outlive the function creating them.
+var small_builtin_map_sink map[uint64]uint64
+var small_DAM_sink *dam.DAM[uint64, uint64]
+
+// Creating many maps of a handful of entries, e.g. one per session...
+func Benchmark_Small_Builtin_Map_New_Set_Get(b *testing.B) {
+	var t uint64
+	for i := 0; i < b.N; i++ {
+		builtin_map := make(map[uint64]uint64, 4)
+		small_builtin_map_sink = builtin_map
+		for k := uint64(1); k <= 4; k++ {
+			builtin_map[k*1024] = k
+		}
+		for k := uint64(1); k <= 4; k++ {
+			t += builtin_map[k*1024]
+		}
+	}
+}
+
+func Benchmark_Small_DAM_New_Set_Get(b *testing.B) {
+	var t uint64
+	for i := 0; i < b.N; i++ {
+		dam_map := dam.New[uint64, uint64](4)
+		small_DAM_sink = dam_map
+		for k := uint64(1); k <= 4; k++ {
+			dam_map.Set(k*1024, k)
+		}
+		for k := uint64(1); k <= 4; k++ {
+			x, _ := dam_map.Get(k * 1024)
+			t += x
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*/
+ ** This software is covered by the MIT License.
+ ** See: `./LICENSE`.
+/*/
+
+package dam_tests
+
+import (
+	"testing"
+
+	"github.com/nacioboi/go_dam/dam/dam"
+)
+
+func Test_Zero_Key_Set_Get_Delete(t *testing.T) {
+	option_sets := map[string][]dam.T_Option[uint64, uint64]{
+		"Default":     nil,
+		"Mixer":       {dam.With_Hash_Mixer[uint64, uint64](dam.HASH_MIXER__SPLITMIX64)},
+		"Range":       {dam.With_Range_Reduction[uint64, uint64](true)},
+		"Incremental": {dam.With_Incremental_Resize[uint64, uint64](1)},
+	}
+
+	for name, options := range option_sets {
+		dam_map := dam.New(uint64(64), options...)
+
+		if _, ok := dam_map.Get(0); ok {
+			t.Fatalf("%s: key 0 found in an empty map.", name)
+		}
+
+		dam_map.Set(0, 100)
+		if v, ok := dam_map.Get(0); !ok || v != 100 {
+			t.Fatalf("%s: Get(0) = (%d, %v), expected (100, true).", name, v, ok)
+		}
+		dam_map.Set(0, 200)
+		if dam_map.Len() != 1 {
+			t.Fatalf("%s: Len() = %d after overwriting key 0, expected 1.", name, dam_map.Len())
+		}
+
+		// Key 0 must survive growth...
+		for i := uint64(1); i <= 1024; i++ {
+			dam_map.Set(i, i)
+		}
+		if v, ok := dam_map.Get(0); !ok || v != 200 {
+			t.Fatalf("%s: Get(0) = (%d, %v) after growth, expected (200, true).", name, v, ok)
+		}
+		if dam_map.Len() != 1025 {
+			t.Fatalf("%s: Len() = %d, expected 1025.", name, dam_map.Len())
+		}
+
+		if !dam_map.Delete(0) {
+			t.Fatalf("%s: Delete(0) did not find the key.", name)
+		}
+		if _, ok := dam_map.Get(0); ok {
+			t.Fatalf("%s: key 0 still present after Delete.", name)
+		}
+		if dam_map.Delete(0) {
+			t.Fatalf("%s: second Delete(0) reported success.", name)
+		}
+		if dam_map.Len() != 1024 {
+			t.Fatalf("%s: Len() = %d after deleting key 0, expected 1024.", name, dam_map.Len())
+		}
+	}
+}
+
+func Test_Zero_Key_Iteration_And_Stats(t *testing.T) {
+	dam_map := dam.New[uint8, string](16)
+	dam_map.Set(0, "zero")
+	dam_map.Set(255, "max")
+
+	seen := make(map[uint8]string)
+	for k, v := range dam_map.All() {
+		seen[k] = v
+	}
+	if len(seen) != 2 || seen[0] != "zero" || seen[255] != "max" {
+		t.Fatalf("All yielded %v.", seen)
+	}
+
+	if s := dam_map.Stats(); s.Num_Entries != 2 {
+		t.Fatalf("Stats reported %d entries, expected 2.", s.Num_Entries)
+	}
+
+	// The whole range of a small key type fits, 0 included...
+	for k := 0; k <= 255; k++ {
+		dam_map.Set(uint8(k), "")
+	}
+	if dam_map.Len() != 256 {
+		t.Fatalf("Len() = %d after setting every uint8 key, expected 256.", dam_map.Len())
+	}
+}
+
+func Test_Zero_Key_SFDA_Resizable_Map(t *testing.T) {
+	m := dam.New_SFDA_Resizable_Map[uint64, uint64](64)
+	m.Set(0, 1)
+	if v, ok := m.Get(0); !ok || v != 1 {
+		t.Fatalf("Get(0) = (%d, %v), expected (1, true).", v, ok)
+	}
+	if !m.Delete(0) || m.Len() != 0 {
+		t.Fatalf("Delete(0) failed or Len() = %d.", m.Len())
+	}
+}